@@ -0,0 +1,139 @@
+package xiter
+
+import (
+	"context"
+	"iter"
+)
+
+// ============================================================================
+// Channels
+// ============================================================================
+
+// FromChan yields the values received from ch until ch is closed or ctx is
+// done, whichever comes first. Breaking early leaves the remaining values in
+// ch. Use ForEachCtx or TryForEachCtx as the terminal to tell cancellation
+// from a closed channel.
+//
+//	ch := make(chan int, 3)
+//	ch <- 1; ch <- 2; close(ch)
+//	FromChan(ctx, ch)  // yields 1, 2
+func FromChan[E any](ctx context.Context, ch <-chan E) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for ctx.Err() == nil {
+			select {
+			case e, ok := <-ch:
+				if !ok || !yield(e) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// FromChan2 is the iter.Seq2 variant of FromChan: it yields the key and value
+// of every KeyValue received from ch.
+func FromChan2[K, V any](ctx context.Context, ch <-chan KeyValue[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for kv := range FromChan(ctx, ch) {
+			if !yield(kv.Key, kv.Value) {
+				return
+			}
+		}
+	}
+}
+
+// ToChan starts a goroutine that sends the elements of s on the returned
+// channel, which has a buffer of buf elements (unbuffered when buf <= 0), and
+// closes it once s is exhausted. The goroutine stops and closes the channel
+// as soon as ctx is done, so a receiver that stops reading early must cancel
+// ctx to release it. s runs on that goroutine, and a panic in s is not
+// recovered.
+//
+//	for v := range ToChan(ctx, Range1(3), 0) { ... }  // receives 0, 1, 2
+func ToChan[E any](ctx context.Context, s iter.Seq[E], buf int) <-chan E {
+	ch := make(chan E, max(buf, 0))
+	go func() {
+		defer close(ch)
+		for e := range WithContext(ctx, s) {
+			select {
+			case ch <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// ToChan2 is the iter.Seq2 variant of ToChan: it sends every pair of s as a
+// KeyValue.
+func ToChan2[K, V any](ctx context.Context, s iter.Seq2[K, V], buf int) <-chan KeyValue[K, V] {
+	return ToChan(ctx, keyValues(s), buf)
+}
+
+// Buffered runs s on its own goroutine, up to n elements ahead of the
+// consumer, so that a slow producer and a slow consumer overlap instead of
+// taking turns. When n <= 0, elements are handed over one at a time without
+// buffering. Element order is preserved.
+//
+// Each iteration starts a new goroutine. When the consumer breaks early, the
+// goroutine stops at its next element, buffered elements are discarded, and
+// it has exited before iteration returns. A panic in s is re-raised on the
+// consumer's goroutine after the elements produced before it. s must be safe
+// to run on a goroutine other than the consumer's.
+//
+//	Buffered(Map(lines, parse), 64)  // parse up to 64 lines ahead
+func Buffered[E any](s iter.Seq[E], n int) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		ch := make(chan E, max(n, 0))
+		done := make(chan struct{})
+		var p any
+		panicked := false
+		go func() {
+			defer close(ch)
+			defer func() {
+				if r := recover(); r != nil {
+					p, panicked = r, true
+				}
+			}()
+			for e := range s {
+				select {
+				case ch <- e:
+				case <-done:
+					return
+				}
+			}
+		}()
+		defer func() {
+			close(done)
+			for range ch {
+			}
+		}()
+		for e := range ch {
+			if !yield(e) {
+				return
+			}
+		}
+		if panicked {
+			panic(p)
+		}
+	}
+}
+
+// Buffered2 is the iter.Seq2 variant of Buffered.
+func Buffered2[K, V any](s iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for kv := range Buffered(zipPairs(s), n) {
+			if !yield(kv.k, kv.v) {
+				return
+			}
+		}
+	}
+}
+
+// keyValues packs each key/value pair of s into a KeyValue.
+func keyValues[K, V any](s iter.Seq2[K, V]) iter.Seq[KeyValue[K, V]] {
+	return Join(s, func(k K, v V) KeyValue[K, V] { return KeyValue[K, V]{k, v} })
+}
@@ -0,0 +1,138 @@
+package xiter
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFromChan(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+	if got := ToSlice(FromChan(context.Background(), ch)); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Fatalf("got %v, want [1 2 3]", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	open := make(chan int, 1)
+	open <- 1
+	var got []int
+	for v := range FromChan(ctx, open) {
+		got = append(got, v)
+		cancel()
+	}
+	if !reflect.DeepEqual(got, []int{1}) {
+		t.Fatalf("got %v after cancel, want [1]", got)
+	}
+	stopEarly(FromChan(context.Background(), ch))
+}
+
+func TestFromChan2(t *testing.T) {
+	ch := make(chan KeyValue[string, int], 2)
+	ch <- KeyValue[string, int]{"a", 1}
+	ch <- KeyValue[string, int]{"b", 2}
+	close(ch)
+	if got := ToMap(FromChan2(context.Background(), ch)); !reflect.DeepEqual(got, map[string]int{"a": 1, "b": 2}) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestToChan(t *testing.T) {
+	var got []int
+	for v := range ToChan(context.Background(), Range1(4), 2) {
+		got = append(got, v)
+	}
+	if !reflect.DeepEqual(got, []int{0, 1, 2, 3}) {
+		t.Fatalf("got %v, want [0 1 2 3]", got)
+	}
+
+	// Cancelling releases a producer blocked on an abandoned channel.
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := ToChan(ctx, Repeat(1), 0)
+	<-ch
+	cancel()
+	select {
+	case <-drained(ch):
+	case <-time.After(5 * time.Second):
+		t.Fatal("producer did not stop after cancel")
+	}
+}
+
+func TestToChan2(t *testing.T) {
+	type kv = struct {
+		K string
+		V int
+	}
+	got := ToMap(FromChan2(context.Background(), ToChan2(context.Background(), seq2Of(kv{"a", 1}, kv{"b", 2}), 0)))
+	if !reflect.DeepEqual(got, map[string]int{"a": 1, "b": 2}) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestBuffered(t *testing.T) {
+	s := Buffered(Range1(100), 8)
+	if got := ToSlice(s); !reflect.DeepEqual(got, ToSlice(Range1(100))) {
+		t.Fatalf("got %v", got)
+	}
+	if got := ToSlice(Buffered(Range1(3), 0)); !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Fatalf("unbuffered got %v, want [0 1 2]", got)
+	}
+
+	// Breaking early stops the producer before iteration returns.
+	src, _, released := trackedSource(1, 2, 3, 4, 5)
+	for range Buffered[int](src, 1) {
+		break
+	}
+	if !*released {
+		t.Fatal("producer still running after early break")
+	}
+	stopEarly(Buffered(Repeat(1), 4))
+}
+
+func TestBufferedPanic(t *testing.T) {
+	src := func(yield func(int) bool) {
+		_ = yield(1) && yield(2)
+		panic("boom")
+	}
+	var got []int
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Fatalf("recovered %v, want boom", r)
+		}
+		if !reflect.DeepEqual(got, []int{1, 2}) {
+			t.Fatalf("got %v before panic, want [1 2]", got)
+		}
+	}()
+	for v := range Buffered(src, 4) {
+		got = append(got, v)
+	}
+}
+
+func TestBuffered2(t *testing.T) {
+	type kv = struct {
+		K string
+		V int
+	}
+	s := Buffered2(seq2Of(kv{"a", 1}, kv{"b", 2}), 1)
+	if got := ToMap(s); !reflect.DeepEqual(got, map[string]int{"a": 1, "b": 2}) {
+		t.Fatalf("got %v", got)
+	}
+	stopEarly2(s)
+}
+
+// drained returns a channel that is closed once ch has been closed, reading
+// and discarding whatever is sent in the meantime.
+func drained[E any](ch <-chan E) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range ch {
+		}
+	}()
+	return done
+}
@@ -158,3 +158,35 @@ func ExampleToValues() {
 	fmt.Println(got)
 	// Output: [a b c]
 }
+
+func ExampleToSketch() {
+	// Sketch each shard separately, then merge for the overall percentiles.
+	shards := [][]int{{1, 5, 9}, {2, 6, 10}, {3, 7}, {4, 8}}
+	total := xiter.NewSketch(0)
+	for _, shard := range shards {
+		total.Merge(collector.Collect(slices.Values(shard), collector.ToSketch[int](0)))
+	}
+	fmt.Println(total.Count(), total.Quantile(0.5), total.Quantile(1))
+	// Output: 10 5 10
+}
+
+func ExampleTopKBy() {
+	type request struct {
+		path    string
+		latency int
+	}
+	reqs := seqOf(
+		request{"/a", 120}, request{"/b", 30}, request{"/a", 340},
+		request{"/b", 95}, request{"/a", 80}, request{"/b", 95},
+	)
+	slowest := collector.GroupingByDownstream(
+		func(r request) string { return r.path },
+		collector.TopKBy(2, func(r request) int { return r.latency }),
+	)(reqs)
+	for _, path := range slices.Sorted(maps.Keys(slowest)) {
+		fmt.Println(path, slowest[path])
+	}
+	// Output:
+	// /a [{/a 340} {/a 120}]
+	// /b [{/b 95} {/b 95}]
+}
@@ -1,8 +1,11 @@
 package collector
 
 import (
+	"cmp"
 	"iter"
 	"strings"
+
+	"github.com/go-board/xiter"
 )
 
 // Partition holds the two halves produced by PartitioningBy. Either field may
@@ -163,3 +166,43 @@ func PartitioningBy[E any](pred func(E) bool) Collector[E, Partition[E]] {
 		return p
 	}
 }
+
+// TopK returns a collector of the k largest elements according to cmp,
+// largest first, keeping only k elements in memory; see xiter.TopK. Ties are
+// stable by arrival. An empty input or k <= 0 yields a nil slice.
+func TopK[E any](k int, cmp func(E, E) int) Collector[E, []E] {
+	return func(s iter.Seq[E]) []E { return xiter.TopK(s, k, cmp) }
+}
+
+// BottomK returns a collector of the k smallest elements according to cmp,
+// smallest first; see xiter.BottomK.
+func BottomK[E any](k int, cmp func(E, E) int) Collector[E, []E] {
+	return func(s iter.Seq[E]) []E { return xiter.BottomK(s, k, cmp) }
+}
+
+// TopKBy returns a collector of the k elements with the largest key, largest
+// first; see xiter.TopKBy.
+//
+//	GroupingByDownstream(host, TopKBy(3, func(r Request) int { return r.Latency }))
+func TopKBy[E any, K cmp.Ordered](k int, key func(E) K) Collector[E, []E] {
+	return func(s iter.Seq[E]) []E { return xiter.TopKBy(s, k, key) }
+}
+
+// BottomKBy returns a collector of the k elements with the smallest key,
+// smallest first; see xiter.BottomKBy.
+func BottomKBy[E any, K cmp.Ordered](k int, key func(E) K) Collector[E, []E] {
+	return func(s iter.Seq[E]) []E { return xiter.BottomKBy(s, k, key) }
+}
+
+// ToSketch returns a collector that feeds every element into a new
+// xiter.Sketch with accuracy parameter k, for approximate quantiles in
+// bounded memory. Sketches collected from separate shards can be combined
+// with Sketch.Merge. An empty input yields an empty sketch.
+//
+//	sk := Collect(latencies, ToSketch[float64](0))
+//	p99 := sk.Quantile(0.99)
+func ToSketch[N xiter.Number](k int) Collector[N, *xiter.Sketch] {
+	return func(s iter.Seq[N]) *xiter.Sketch {
+		return xiter.ToSketch(s, k)
+	}
+}
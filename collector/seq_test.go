@@ -1,6 +1,7 @@
 package collector
 
 import (
+	"cmp"
 	"iter"
 	"reflect"
 	"slices"
@@ -195,3 +196,37 @@ func TestPartitioningBy(t *testing.T) {
 		}
 	})
 }
+
+func TestToSketch(t *testing.T) {
+	sk := Collect(xiter.Range2(1, 1001), ToSketch[int](0))
+	if sk.Count() != 1000 {
+		t.Fatalf("Count got %d, want 1000", sk.Count())
+	}
+	// The default k keeps ranks within 0.85% of n, i.e. about 9 positions.
+	if p50 := sk.Quantile(0.5); p50 < 491 || p50 > 509 {
+		t.Fatalf("median got %v, want about 500", p50)
+	}
+	if got := ToSketch[float64](0)(seqOf[float64]()); got.Count() != 0 {
+		t.Fatalf("empty got count %d", got.Count())
+	}
+}
+
+func TestTopK(t *testing.T) {
+	s := seqOf(3, 1, 4, 1, 5, 9, 2, 6)
+	if got := TopK(3, cmp.Compare[int])(s); !slices.Equal(got, []int{9, 6, 5}) {
+		t.Fatalf("TopK got %v, want [9 6 5]", got)
+	}
+	if got := BottomK(2, cmp.Compare[int])(s); !slices.Equal(got, []int{1, 1}) {
+		t.Fatalf("BottomK got %v, want [1 1]", got)
+	}
+	neg := func(n int) int { return -n }
+	if got := TopKBy(2, neg)(s); !slices.Equal(got, []int{1, 1}) {
+		t.Fatalf("TopKBy got %v, want [1 1]", got)
+	}
+	if got := BottomKBy(2, neg)(s); !slices.Equal(got, []int{9, 6}) {
+		t.Fatalf("BottomKBy got %v, want [9 6]", got)
+	}
+	if got := TopK(3, cmp.Compare[int])(seqOf[int]()); got != nil {
+		t.Fatalf("empty got %v, want nil", got)
+	}
+}
@@ -0,0 +1,287 @@
+package xiter
+
+import "iter"
+
+// ============================================================================
+// Combinatorics
+// ============================================================================
+//
+// The generators below enumerate arrangements of the elements of a slice. They
+// are lazy: each arrangement is computed only when the consumer asks for it,
+// so Take(Permutations(items, k), 10) performs ten steps of work regardless of
+// how many permutations exist. Elements are selected by position, so equal
+// elements at different positions are treated as distinct, and arrangements
+// are yielded in lexicographic order of those positions, following Python's
+// itertools.
+//
+// The input slices are not copied; they must not be modified while the
+// returned sequence is being iterated.
+//
+// Every yielded slice is freshly allocated and may be retained or modified by
+// the consumer. The ...Reuse variants instead yield the same backing buffer
+// for every arrangement; a yielded slice is then only valid until the next
+// iteration step.
+
+// CartesianPair yields every pair (e1, e2) with e1 from x and e2 from y,
+// iterating y fastest. The result is empty when either input is empty.
+//
+//	CartesianPair([]int{1, 2}, []string{"a", "b"})
+//	// yields (1, "a"), (1, "b"), (2, "a"), (2, "b")
+func CartesianPair[E1, E2 any](x []E1, y []E2) iter.Seq2[E1, E2] {
+	return func(yield func(E1, E2) bool) {
+		for _, e1 := range x {
+			for _, e2 := range y {
+				if !yield(e1, e2) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// CartesianProduct yields every slice that takes one element from each input,
+// in order, with the last input varying fastest. It yields a single empty
+// slice when no inputs are given and nothing when any input is empty.
+//
+//	CartesianProduct([]int{1, 2}, []int{3, 4})
+//	// yields [1 3], [1 4], [2 3], [2 4]
+func CartesianProduct[E any](seqs ...[]E) iter.Seq[[]E] {
+	return cartesianProduct(seqs, false)
+}
+
+// CartesianProductReuse is like CartesianProduct but yields the same backing
+// buffer for every slice.
+func CartesianProductReuse[E any](seqs ...[]E) iter.Seq[[]E] {
+	return cartesianProduct(seqs, true)
+}
+
+func cartesianProduct[E any](seqs [][]E, reuse bool) iter.Seq[[]E] {
+	return func(yield func([]E) bool) {
+		for _, s := range seqs {
+			if len(s) == 0 {
+				return
+			}
+		}
+		indices := make([]int, len(seqs))
+		buf := make([]E, len(seqs))
+		for {
+			if !reuse {
+				buf = make([]E, len(seqs))
+			}
+			for i, idx := range indices {
+				buf[i] = seqs[i][idx]
+			}
+			if !yield(buf) {
+				return
+			}
+			// Advance the indices like an odometer, rightmost first.
+			i := len(indices) - 1
+			for ; i >= 0; i-- {
+				indices[i]++
+				if indices[i] < len(seqs[i]) {
+					break
+				}
+				indices[i] = 0
+			}
+			if i < 0 {
+				return
+			}
+		}
+	}
+}
+
+// Combinations yields every k-element subset of items, preserving the order
+// of items within each subset. It yields a single empty slice when k == 0 and
+// nothing when k < 0 or k > len(items).
+//
+//	Combinations([]int{1, 2, 3, 4}, 2)
+//	// yields [1 2], [1 3], [1 4], [2 3], [2 4], [3 4]
+func Combinations[E any](items []E, k int) iter.Seq[[]E] {
+	return combinations(items, k, false)
+}
+
+// CombinationsReuse is like Combinations but yields the same backing buffer
+// for every subset.
+func CombinationsReuse[E any](items []E, k int) iter.Seq[[]E] {
+	return combinations(items, k, true)
+}
+
+func combinations[E any](items []E, k int, reuse bool) iter.Seq[[]E] {
+	return func(yield func([]E) bool) {
+		if k < 0 || k > len(items) {
+			return
+		}
+		yieldCombinations(items, k, make([]E, k), reuse, yield)
+	}
+}
+
+// yieldCombinations yields the k-element subsets of items, writing them into
+// buf when reuse is set. It reports whether the consumer wants more.
+func yieldCombinations[E any](items []E, k int, buf []E, reuse bool, yield func([]E) bool) bool {
+	n := len(items)
+	indices := make([]int, k)
+	for i := range indices {
+		indices[i] = i
+	}
+	for {
+		if !yield(pick(items, indices, buf, reuse)) {
+			return false
+		}
+		// Find the rightmost index that has not reached its final position,
+		// bump it and reset every index after it.
+		i := k - 1
+		for i >= 0 && indices[i] == i+n-k {
+			i--
+		}
+		if i < 0 {
+			return true
+		}
+		indices[i]++
+		for j := i + 1; j < k; j++ {
+			indices[j] = indices[j-1] + 1
+		}
+	}
+}
+
+// CombinationsWithReplacement yields every k-element multiset drawn from
+// items, where each element may be chosen more than once. It yields a single
+// empty slice when k == 0 and nothing when k < 0 or items is empty and k > 0.
+//
+//	CombinationsWithReplacement([]int{1, 2, 3}, 2)
+//	// yields [1 1], [1 2], [1 3], [2 2], [2 3], [3 3]
+func CombinationsWithReplacement[E any](items []E, k int) iter.Seq[[]E] {
+	return combinationsWithReplacement(items, k, false)
+}
+
+// CombinationsWithReplacementReuse is like CombinationsWithReplacement but
+// yields the same backing buffer for every multiset.
+func CombinationsWithReplacementReuse[E any](items []E, k int) iter.Seq[[]E] {
+	return combinationsWithReplacement(items, k, true)
+}
+
+func combinationsWithReplacement[E any](items []E, k int, reuse bool) iter.Seq[[]E] {
+	return func(yield func([]E) bool) {
+		n := len(items)
+		if k < 0 || (n == 0 && k > 0) {
+			return
+		}
+		indices := make([]int, k)
+		buf := make([]E, k)
+		for {
+			if !yield(pick(items, indices, buf, reuse)) {
+				return
+			}
+			i := k - 1
+			for i >= 0 && indices[i] == n-1 {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			v := indices[i] + 1
+			for j := i; j < k; j++ {
+				indices[j] = v
+			}
+		}
+	}
+}
+
+// Permutations yields every ordered arrangement of k distinct positions of
+// items. Pass len(items) as k to enumerate full permutations. It yields a
+// single empty slice when k == 0 and nothing when k < 0 or k > len(items).
+//
+//	Permutations([]int{1, 2, 3}, 2)
+//	// yields [1 2], [1 3], [2 1], [2 3], [3 1], [3 2]
+func Permutations[E any](items []E, k int) iter.Seq[[]E] {
+	return permutations(items, k, false)
+}
+
+// PermutationsReuse is like Permutations but yields the same backing buffer
+// for every arrangement.
+func PermutationsReuse[E any](items []E, k int) iter.Seq[[]E] {
+	return permutations(items, k, true)
+}
+
+func permutations[E any](items []E, k int, reuse bool) iter.Seq[[]E] {
+	return func(yield func([]E) bool) {
+		n := len(items)
+		if k < 0 || k > n {
+			return
+		}
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		// cycles[i] counts how many more values position i takes before it
+		// wraps around and the position to its left advances.
+		cycles := make([]int, k)
+		for i := range cycles {
+			cycles[i] = n - i
+		}
+		buf := make([]E, k)
+		if !yield(pick(items, indices[:k], buf, reuse)) {
+			return
+		}
+	next:
+		for {
+			for i := k - 1; i >= 0; i-- {
+				cycles[i]--
+				if cycles[i] == 0 {
+					// Rotate indices[i:] left by one, restoring the order
+					// it had before position i started cycling.
+					first := indices[i]
+					copy(indices[i:], indices[i+1:])
+					indices[n-1] = first
+					cycles[i] = n - i
+					continue
+				}
+				j := n - cycles[i]
+				indices[i], indices[j] = indices[j], indices[i]
+				if !yield(pick(items, indices[:k], buf, reuse)) {
+					return
+				}
+				continue next
+			}
+			return
+		}
+	}
+}
+
+// PowerSet yields every subset of items, ordered by size and then
+// lexicographically by position, starting with the empty subset. A slice of n
+// elements has 2^n subsets.
+//
+//	PowerSet([]int{1, 2, 3})
+//	// yields [], [1], [2], [3], [1 2], [1 3], [2 3], [1 2 3]
+func PowerSet[E any](items []E) iter.Seq[[]E] {
+	return powerSet(items, false)
+}
+
+// PowerSetReuse is like PowerSet but yields the same backing buffer for
+// every subset.
+func PowerSetReuse[E any](items []E) iter.Seq[[]E] {
+	return powerSet(items, true)
+}
+
+func powerSet[E any](items []E, reuse bool) iter.Seq[[]E] {
+	return func(yield func([]E) bool) {
+		buf := make([]E, len(items))
+		for k := 0; k <= len(items); k++ {
+			if !yieldCombinations(items, k, buf[:k], reuse, yield) {
+				return
+			}
+		}
+	}
+}
+
+// pick fills the result slice with the elements of items at indices. When
+// reuse is false, a fresh slice is allocated instead of writing into buf.
+func pick[E any](items []E, indices []int, buf []E, reuse bool) []E {
+	if !reuse {
+		buf = make([]E, len(indices))
+	}
+	for i, idx := range indices {
+		buf[i] = items[idx]
+	}
+	return buf
+}
@@ -0,0 +1,169 @@
+package xiter
+
+import (
+	"iter"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestCartesianPair(t *testing.T) {
+	var got []string
+	for n, s := range CartesianPair([]int{1, 2}, []string{"a", "b", "c"}) {
+		got = append(got, strconv.Itoa(n)+s)
+	}
+	want := []string{"1a", "1b", "1c", "2a", "2b", "2c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if n := Size2(CartesianPair([]int{1, 2}, []string(nil))); n != 0 {
+		t.Fatalf("got %d pairs, want 0", n)
+	}
+	stopEarly2(CartesianPair([]int{1}, []int{1}))
+}
+
+func TestCartesianProduct(t *testing.T) {
+	got := ToSlice(CartesianProduct([]int{1, 2}, []int{3}, []int{4, 5}))
+	want := [][]int{{1, 3, 4}, {1, 3, 5}, {2, 3, 4}, {2, 3, 5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := ToSlice(CartesianProduct[int]()); !reflect.DeepEqual(got, [][]int{{}}) {
+		t.Fatalf("got %v, want [[]]", got)
+	}
+	if got := ToSlice(CartesianProduct([]int{1, 2}, nil)); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	stopEarly(CartesianProduct([]int{1}))
+}
+
+func TestCombinations(t *testing.T) {
+	got := ToSlice(Combinations([]int{1, 2, 3, 4}, 2))
+	want := [][]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := ToSlice(Combinations([]int{1, 2}, 0)); !reflect.DeepEqual(got, [][]int{{}}) {
+		t.Fatalf("got %v, want [[]]", got)
+	}
+	if got := ToSlice(Combinations([]int{1, 2}, 3)); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	if got := ToSlice(Combinations([]int{1, 2}, -1)); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	if n := Size(Combinations(slices.Collect(Range1(10)), 4)); n != 210 {
+		t.Fatalf("got %d combinations, want 210", n)
+	}
+	stopEarly(Combinations([]int{1, 2}, 1))
+}
+
+func TestCombinationsWithReplacement(t *testing.T) {
+	got := ToSlice(CombinationsWithReplacement([]int{1, 2, 3}, 2))
+	want := [][]int{{1, 1}, {1, 2}, {1, 3}, {2, 2}, {2, 3}, {3, 3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := ToSlice(CombinationsWithReplacement([]int{1}, 3)); !reflect.DeepEqual(got, [][]int{{1, 1, 1}}) {
+		t.Fatalf("got %v, want [[1 1 1]]", got)
+	}
+	if got := ToSlice(CombinationsWithReplacement([]int(nil), 0)); !reflect.DeepEqual(got, [][]int{{}}) {
+		t.Fatalf("got %v, want [[]]", got)
+	}
+	if got := ToSlice(CombinationsWithReplacement([]int(nil), 2)); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	stopEarly(CombinationsWithReplacement([]int{1, 2}, 1))
+}
+
+func TestPermutations(t *testing.T) {
+	got := ToSlice(Permutations([]int{1, 2, 3}, 2))
+	want := [][]int{{1, 2}, {1, 3}, {2, 1}, {2, 3}, {3, 1}, {3, 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	got = ToSlice(Permutations([]int{1, 2, 3}, 3))
+	want = [][]int{{1, 2, 3}, {1, 3, 2}, {2, 1, 3}, {2, 3, 1}, {3, 1, 2}, {3, 2, 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := ToSlice(Permutations([]int{1, 2}, 0)); !reflect.DeepEqual(got, [][]int{{}}) {
+		t.Fatalf("got %v, want [[]]", got)
+	}
+	if got := ToSlice(Permutations([]int{1, 2}, 3)); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	if n := Size(Permutations(slices.Collect(Range1(6)), 4)); n != 360 {
+		t.Fatalf("got %d permutations, want 360", n)
+	}
+	stopEarly(Permutations([]int{1, 2}, 2))
+}
+
+func TestPermutationsLazy(t *testing.T) {
+	// 20! arrangements exist; taking a few must not enumerate them.
+	items := slices.Collect(Range1(20))
+	got := ToSlice(Take(Permutations(items, len(items)), 3))
+	if len(got) != 3 {
+		t.Fatalf("got %d permutations, want 3", len(got))
+	}
+	if !reflect.DeepEqual(got[0], items) {
+		t.Fatalf("first permutation got %v, want %v", got[0], items)
+	}
+}
+
+func TestPowerSet(t *testing.T) {
+	got := ToSlice(PowerSet([]int{1, 2, 3}))
+	want := [][]int{{}, {1}, {2}, {3}, {1, 2}, {1, 3}, {2, 3}, {1, 2, 3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := ToSlice(PowerSet([]int(nil))); !reflect.DeepEqual(got, [][]int{{}}) {
+		t.Fatalf("got %v, want [[]]", got)
+	}
+	stopEarly(PowerSet([]int{1}))
+}
+
+func TestCombinatoricsReuse(t *testing.T) {
+	items := []int{1, 2, 3}
+	for name, s := range map[string]func() ([][]int, [][]int){
+		"CartesianProduct": func() ([][]int, [][]int) {
+			return ToSlice(CartesianProduct(items, items)), cloneAll(CartesianProductReuse(items, items))
+		},
+		"Combinations": func() ([][]int, [][]int) {
+			return ToSlice(Combinations(items, 2)), cloneAll(CombinationsReuse(items, 2))
+		},
+		"CombinationsWithReplacement": func() ([][]int, [][]int) {
+			return ToSlice(CombinationsWithReplacement(items, 2)), cloneAll(CombinationsWithReplacementReuse(items, 2))
+		},
+		"Permutations": func() ([][]int, [][]int) {
+			return ToSlice(Permutations(items, 2)), cloneAll(PermutationsReuse(items, 2))
+		},
+		"PowerSet": func() ([][]int, [][]int) {
+			return ToSlice(PowerSet(items)), cloneAll(PowerSetReuse(items))
+		},
+	} {
+		want, got := s()
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: reuse variant got %v, want %v", name, got, want)
+		}
+	}
+
+	var first *int
+	for c := range PermutationsReuse(items, 2) {
+		if first == nil {
+			first = &c[0]
+		} else if &c[0] != first {
+			t.Fatal("PermutationsReuse allocated a new buffer")
+		}
+	}
+}
+
+// cloneAll collects s, copying every yielded slice.
+func cloneAll[E any](s iter.Seq[[]E]) [][]E {
+	var out [][]E
+	for c := range s {
+		out = append(out, slices.Clone(c))
+	}
+	return out
+}
@@ -0,0 +1,108 @@
+package xiter
+
+import (
+	"context"
+	"iter"
+)
+
+// ============================================================================
+// Context
+// ============================================================================
+
+// WithContext yields the elements of s until ctx is cancelled or its deadline
+// passes, then stops. The context is checked before the source is started and
+// before every element is yielded; an element pulled after cancellation is
+// dropped. WithContext cannot interrupt a source that is blocked inside its
+// own code — it only takes effect between elements.
+//
+// The adapter itself stops silently. Use ForEachCtx, TryForEachCtx or
+// TryFoldCtx as the terminal to observe ctx.Err().
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	for v := range WithContext(ctx, Range1(10)) {
+//	    if v == 2 { cancel() }
+//	}  // visits 0, 1, 2
+func WithContext[E any](ctx context.Context, s iter.Seq[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		if ctx.Err() != nil {
+			return
+		}
+		for e := range s {
+			if ctx.Err() != nil || !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// WithContext2 is the iter.Seq2 variant of WithContext: it yields the pairs
+// of s until ctx is cancelled or its deadline passes, then stops.
+func WithContext2[K, V any](ctx context.Context, s iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if ctx.Err() != nil {
+			return
+		}
+		for k, v := range s {
+			if ctx.Err() != nil || !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// ForEachCtx is like ForEach but stops as soon as ctx is cancelled or its
+// deadline passes. Returns ctx.Err() when the context is done by the time
+// iteration ends, and nil otherwise.
+func ForEachCtx[E any](ctx context.Context, s iter.Seq[E], f func(E)) error {
+	for e := range WithContext(ctx, s) {
+		f(e)
+	}
+	return ctx.Err()
+}
+
+// ForEach2Ctx is the iter.Seq2 variant of ForEachCtx.
+func ForEach2Ctx[K, V any](ctx context.Context, s iter.Seq2[K, V], f func(K, V)) error {
+	for k, v := range WithContext2(ctx, s) {
+		f(k, v)
+	}
+	return ctx.Err()
+}
+
+// TryForEachCtx is like TryForEach but also stops as soon as ctx is cancelled
+// or its deadline passes. Returns the first error from f; otherwise returns
+// ctx.Err() when the context is done by the time iteration ends.
+func TryForEachCtx[E any](ctx context.Context, s iter.Seq[E], f func(E) error) error {
+	if err := TryForEach(WithContext(ctx, s), f); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// TryForEach2Ctx is the iter.Seq2 variant of TryForEachCtx.
+func TryForEach2Ctx[K, V any](ctx context.Context, s iter.Seq2[K, V], f func(K, V) error) error {
+	if err := TryForEach2(WithContext2(ctx, s), f); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// TryFoldCtx is like TryFold but also stops as soon as ctx is cancelled or its
+// deadline passes. Returns the accumulator built so far together with the
+// first error from f, or with ctx.Err() when the context is done by the time
+// iteration ends; on success returns (final accumulator, nil).
+func TryFoldCtx[E, A any](ctx context.Context, s iter.Seq[E], init A, f func(A, E) (A, error)) (A, error) {
+	acc, err := TryFold(WithContext(ctx, s), init, f)
+	if err != nil {
+		return acc, err
+	}
+	return acc, ctx.Err()
+}
+
+// TryFold2Ctx is the iter.Seq2 variant of TryFoldCtx.
+func TryFold2Ctx[K, V, A any](ctx context.Context, s iter.Seq2[K, V], init A, f func(A, K, V) (A, error)) (A, error) {
+	acc, err := TryFold2(WithContext2(ctx, s), init, f)
+	if err != nil {
+		return acc, err
+	}
+	return acc, ctx.Err()
+}
@@ -0,0 +1,231 @@
+package xiter
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWithContext(t *testing.T) {
+	t.Run("not cancelled", func(t *testing.T) {
+		got := ToSlice(WithContext(context.Background(), Range1(5)))
+		want := []int{0, 1, 2, 3, 4}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+	t.Run("cancel mid-stream", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		pulled := 0
+		src := Inspect(Range1(10), func(int) { pulled++ })
+		var got []int
+		for v := range WithContext(ctx, src) {
+			got = append(got, v)
+			if v == 2 {
+				cancel()
+			}
+		}
+		if !reflect.DeepEqual(got, []int{0, 1, 2}) {
+			t.Fatalf("got %v, want [0 1 2]", got)
+		}
+		if pulled != 4 {
+			t.Fatalf("pulled %d elements, want 4", pulled)
+		}
+	})
+	t.Run("already cancelled does not start source", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		started := false
+		src := func(yield func(int) bool) { started = true }
+		if got := ToSlice(WithContext(ctx, src)); len(got) != 0 {
+			t.Fatalf("got %v, want empty", got)
+		}
+		if started {
+			t.Fatal("source started after cancellation")
+		}
+	})
+	t.Run("deadline", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+		if got := ToSlice(WithContext(ctx, Repeat(1))); len(got) != 0 {
+			t.Fatalf("got %v, want empty", got)
+		}
+	})
+	stopEarly(WithContext(context.Background(), Range1(10)))
+}
+
+func TestWithContext2(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	got := map[int]int{}
+	for k, v := range WithContext2(ctx, Enumerate(Range2(10, 20))) {
+		got[k] = v
+		if k == 1 {
+			cancel()
+		}
+	}
+	if !reflect.DeepEqual(got, map[int]int{0: 10, 1: 11}) {
+		t.Fatalf("got %v", got)
+	}
+	cancel()
+	if got := ToMap(WithContext2(ctx, Enumerate(Range1(3)))); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	stopEarly2(WithContext2(context.Background(), Enumerate(Range1(10))))
+}
+
+func TestForEachCtx(t *testing.T) {
+	sum := 0
+	if err := ForEachCtx(context.Background(), Range1(5), func(v int) { sum += v }); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if sum != 10 {
+		t.Fatalf("got %d, want 10", sum)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	visited := 0
+	err := ForEachCtx(ctx, Repeat(1), func(int) {
+		visited++
+		if visited == 3 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+	if visited != 3 {
+		t.Fatalf("visited %d, want 3", visited)
+	}
+}
+
+func TestForEach2Ctx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	visited := 0
+	err := ForEach2Ctx(ctx, Enumerate(Repeat(1)), func(k, _ int) {
+		visited++
+		if k == 1 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+	if visited != 2 {
+		t.Fatalf("visited %d, want 2", visited)
+	}
+}
+
+func TestTryForEachCtx(t *testing.T) {
+	wantErr := errors.New("stop")
+	err := TryForEachCtx(context.Background(), Range1(5), func(v int) error {
+		if v == 1 {
+			return wantErr
+		}
+		return nil
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("got error %v, want %v", err, wantErr)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	err = TryForEachCtx(ctx, Repeat(1), func(int) error {
+		time.Sleep(100 * time.Microsecond)
+		return nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if err := TryForEachCtx(context.Background(), Range1(3), func(int) error { return nil }); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+}
+
+func TestTryForEach2Ctx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := TryForEach2Ctx(ctx, Enumerate(Repeat(1)), func(k, _ int) error {
+		if k == 4 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+
+	wantErr := errors.New("stop")
+	err = TryForEach2Ctx(context.Background(), Enumerate(Range1(5)), func(k, _ int) error {
+		if k == 2 {
+			return wantErr
+		}
+		return nil
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("got error %v, want %v", err, wantErr)
+	}
+}
+
+func TestTryFoldCtx(t *testing.T) {
+	got, err := TryFoldCtx(context.Background(), Range1(5), 0, func(acc, v int) (int, error) {
+		return acc + v, nil
+	})
+	if err != nil || got != 10 {
+		t.Fatalf("got (%d, %v), want (10, nil)", got, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	got, err = TryFoldCtx(ctx, Range1(100), 0, func(acc, v int) (int, error) {
+		if v == 3 {
+			cancel()
+		}
+		return acc + v, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+	if got != 6 {
+		t.Fatalf("got accumulator %d, want 6", got)
+	}
+
+	wantErr := errors.New("stop")
+	got, err = TryFoldCtx(context.Background(), Range1(5), 0, func(acc, v int) (int, error) {
+		if v == 2 {
+			return acc, wantErr
+		}
+		return acc + v, nil
+	})
+	if !errors.Is(err, wantErr) || got != 1 {
+		t.Fatalf("got (%d, %v), want (1, %v)", got, err, wantErr)
+	}
+}
+
+func TestTryFold2Ctx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	got, err := TryFold2Ctx(ctx, Enumerate(Range1(100)), 0, func(acc, k, v int) (int, error) {
+		if k == 2 {
+			cancel()
+		}
+		return acc + v, nil
+	})
+	if !errors.Is(err, context.Canceled) || got != 3 {
+		t.Fatalf("got (%d, %v), want (3, %v)", got, err, context.Canceled)
+	}
+
+	wantErr := errors.New("stop")
+	_, err = TryFold2Ctx(context.Background(), Enumerate(Range1(5)), 0, func(acc, k, v int) (int, error) {
+		return acc, wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("got error %v, want %v", err, wantErr)
+	}
+}
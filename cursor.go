@@ -0,0 +1,170 @@
+package xiter
+
+import "iter"
+
+// ============================================================================
+// Cursor
+// ============================================================================
+
+// Cursor adapts a sequence to the Next/Value/Err/Close iteration style of
+// APIs that predate range-over-func, such as database/sql's Rows:
+//
+//	c := ToCursor(s)
+//	defer c.Close()
+//	for c.Next() {
+//	    use(c.Value())
+//	}
+//	return c.Err()
+//
+// It is built on iter.Pull. The sequence is started on the first call to
+// Next and released once it is exhausted or Close is called; a Cursor that is
+// abandoned before either keeps the sequence suspended, so always Close it.
+// Close uses no finalizer. A Cursor is not safe for concurrent use.
+type Cursor[E any] struct {
+	src  iter.Seq[E]
+	next func() (E, bool)
+	stop func()
+
+	cur  E
+	done bool
+}
+
+// ToCursor returns a Cursor over s. No element is pulled until Next is
+// called.
+func ToCursor[E any](s iter.Seq[E]) *Cursor[E] {
+	return &Cursor[E]{src: s}
+}
+
+// Next advances the cursor to the next element, which Value then returns.
+// It reports false once the sequence is exhausted or the cursor is closed;
+// the sequence is released at that point.
+func (c *Cursor[E]) Next() bool {
+	if c.done {
+		return false
+	}
+	if c.next == nil {
+		c.next, c.stop = iter.Pull(c.src)
+	}
+	e, ok := c.next()
+	if !ok {
+		c.Close()
+		return false
+	}
+	c.cur = e
+	return true
+}
+
+// Value returns the element at the cursor's current position: the zero value
+// before the first call to Next and after Next has reported false.
+func (c *Cursor[E]) Value() E { return c.cur }
+
+// Err returns the error that stopped iteration. A plain sequence cannot fail,
+// so Err always returns nil; it exists so that a Cursor satisfies the
+// Next/Err/Close interfaces that cursor-consuming code expects.
+func (c *Cursor[E]) Err() error { return nil }
+
+// Close releases the sequence. It is idempotent, safe to call after the
+// cursor is exhausted, and always returns nil. After Close, Next reports
+// false.
+func (c *Cursor[E]) Close() error {
+	var zero E
+	c.cur = zero
+	if c.done {
+		return nil
+	}
+	c.done = true
+	if c.stop != nil {
+		c.stop()
+	}
+	return nil
+}
+
+// Seq converts the cursor back into a sequence over its remaining elements,
+// i.e. those after the current position. The sequence takes ownership of the
+// cursor and closes it when it ends, whether exhausted or stopped early.
+func (c *Cursor[E]) Seq() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		defer c.Close()
+		for c.Next() {
+			if !yield(c.cur) {
+				return
+			}
+		}
+	}
+}
+
+// Cursor2 is the key/value variant of Cursor, built on iter.Pull2: Next
+// advances to the next pair, which Key and Value return.
+type Cursor2[K, V any] struct {
+	src  iter.Seq2[K, V]
+	next func() (K, V, bool)
+	stop func()
+
+	key  K
+	val  V
+	done bool
+}
+
+// ToCursor2 returns a Cursor2 over s. No pair is pulled until Next is
+// called.
+func ToCursor2[K, V any](s iter.Seq2[K, V]) *Cursor2[K, V] {
+	return &Cursor2[K, V]{src: s}
+}
+
+// Next advances the cursor to the next pair and reports false once the
+// sequence is exhausted or the cursor is closed.
+func (c *Cursor2[K, V]) Next() bool {
+	if c.done {
+		return false
+	}
+	if c.next == nil {
+		c.next, c.stop = iter.Pull2(c.src)
+	}
+	k, v, ok := c.next()
+	if !ok {
+		c.Close()
+		return false
+	}
+	c.key, c.val = k, v
+	return true
+}
+
+// Key returns the key of the current pair, or the zero value when there is
+// none.
+func (c *Cursor2[K, V]) Key() K { return c.key }
+
+// Value returns the value of the current pair, or the zero value when there
+// is none.
+func (c *Cursor2[K, V]) Value() V { return c.val }
+
+// Err always returns nil; see Cursor.Err.
+func (c *Cursor2[K, V]) Err() error { return nil }
+
+// Close releases the sequence. It is idempotent, safe to call after the
+// cursor is exhausted, and always returns nil.
+func (c *Cursor2[K, V]) Close() error {
+	var zk K
+	var zv V
+	c.key, c.val = zk, zv
+	if c.done {
+		return nil
+	}
+	c.done = true
+	if c.stop != nil {
+		c.stop()
+	}
+	return nil
+}
+
+// Seq converts the cursor back into a sequence over its remaining pairs. The
+// sequence takes ownership of the cursor and closes it when it ends.
+func (c *Cursor2[K, V]) Seq() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		defer c.Close()
+		for c.Next() {
+			if !yield(c.key, c.val) {
+				return
+			}
+		}
+	}
+}
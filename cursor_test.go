@@ -0,0 +1,97 @@
+package xiter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	src, started, released := trackedSource(1, 2, 3)
+	c := ToCursor[int](src)
+	if *started {
+		t.Fatal("source started before Next")
+	}
+	if v := c.Value(); v != 0 {
+		t.Fatalf("Value before Next got %v, want 0", v)
+	}
+	var got []int
+	for c.Next() {
+		got = append(got, c.Value())
+	}
+	if !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Fatalf("got %v, want [1 2 3]", got)
+	}
+	if !*released {
+		t.Fatal("source not released after exhaustion")
+	}
+	if c.Next() || c.Value() != 0 || c.Err() != nil {
+		t.Fatal("exhausted cursor reported more elements")
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close after exhaustion got %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("second Close got %v", err)
+	}
+}
+
+func TestCursorClose(t *testing.T) {
+	src, _, released := trackedSource(1, 2, 3)
+	c := ToCursor[int](src)
+	c.Next()
+	if err := c.Close(); err != nil || !*released {
+		t.Fatalf("Close got %v, released %v", err, *released)
+	}
+	if c.Next() {
+		t.Fatal("Next after Close reported an element")
+	}
+
+	// Closing an unused cursor must not start the source.
+	src, started, _ := trackedSource(1)
+	if err := ToCursor[int](src).Close(); err != nil || *started {
+		t.Fatalf("Close got %v, started %v", err, *started)
+	}
+}
+
+func TestCursorSeq(t *testing.T) {
+	c := ToCursor(Range1(5))
+	c.Next()
+	if got := ToSlice(Take(c.Seq(), 2)); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("got %v, want [1 2]", got)
+	}
+	if c.Next() {
+		t.Fatal("cursor still open after its Seq stopped")
+	}
+	c = ToCursor(Range1(3))
+	if got := ToSlice(c.Seq()); !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Fatalf("got %v, want [0 1 2]", got)
+	}
+}
+
+func TestCursor2(t *testing.T) {
+	type kv = struct {
+		K string
+		V int
+	}
+	c := ToCursor2(seq2Of(kv{"a", 1}, kv{"b", 2}, kv{"c", 3}))
+	defer c.Close()
+	if !c.Next() || c.Key() != "a" || c.Value() != 1 || c.Err() != nil {
+		t.Fatalf("got (%v, %v)", c.Key(), c.Value())
+	}
+	if got := ToMap(c.Seq()); !reflect.DeepEqual(got, map[string]int{"b": 2, "c": 3}) {
+		t.Fatalf("got %v", got)
+	}
+	if c.Next() || c.Key() != "" || c.Value() != 0 {
+		t.Fatal("closed cursor reported a pair")
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close got %v", err)
+	}
+
+	c = ToCursor2(seq2Of(kv{"a", 1}))
+	c.Next()
+	stopEarly2(c.Seq())
+	if c.Next() {
+		t.Fatal("cursor still open after its Seq stopped")
+	}
+}
@@ -1,8 +1,14 @@
 package xiter_test
 
 import (
+	"cmp"
+	"context"
+	"errors"
 	"fmt"
+	"iter"
 	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/go-board/xiter"
 )
@@ -243,6 +249,15 @@ func ExampleStepBy2() {
 	// 9:9
 }
 
+func ExampleChunks2() {
+	for ks, vs := range xiter.Chunks2(slices.All([]string{"a", "b", "c"}), 2) {
+		fmt.Println(ks, vs)
+	}
+	// Output:
+	// [0 1] [a b]
+	// [2] [c]
+}
+
 func ExampleChain2() {
 	seq1 := slices.All([]int{1, 2})
 	seq2 := slices.All([]int{3, 4})
@@ -474,3 +489,125 @@ func ExampleEqualFunc2() {
 	// true
 	// false
 }
+
+// ============================================================================
+// Context
+// ============================================================================
+
+func ExampleWithContext2() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for k, v := range xiter.WithContext2(ctx, slices.All([]string{"a", "b", "c"})) {
+		fmt.Printf("%d:%s\n", k, v)
+		if k == 1 {
+			cancel()
+		}
+	}
+	// Output:
+	// 0:a
+	// 1:b
+}
+
+func ExampleTryForEach2Ctx() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := xiter.TryForEach2Ctx(ctx, slices.All([]int{1, 2, 3}), func(k, v int) error {
+		fmt.Printf("%d:%d\n", k, v)
+		return nil
+	})
+	fmt.Println(err)
+	// Output:
+	// context canceled
+}
+
+// ============================================================================
+// Parallel
+// ============================================================================
+
+func ExampleParallelMap2() {
+	src := slices.All([]string{"a", "b", "c"})
+	upper := xiter.ParallelMap2(src, 2, func(i int, s string) (int, string) {
+		return i + 1, strings.ToUpper(s)
+	})
+	for k, v := range upper {
+		fmt.Printf("%d:%s\n", k, v)
+	}
+	// Output:
+	// 1:A
+	// 2:B
+	// 3:C
+}
+
+// ============================================================================
+// Error-carrying sequences
+// ============================================================================
+
+// records yields each string together with a nil error, except "!" which
+// stands for a failed read.
+func records(ss ...string) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		for _, s := range ss {
+			var err error
+			if s == "!" {
+				err = errors.New("read failed")
+			}
+			if !yield(s, err) {
+				return
+			}
+		}
+	}
+}
+
+func ExampleMapErr() {
+	for n, err := range xiter.MapErr(records("1", "!", "3"), strconv.Atoi) {
+		fmt.Println(n, err)
+	}
+	// Output:
+	// 1 <nil>
+	// 0 read failed
+	// 3 <nil>
+}
+
+func ExampleCollectErr() {
+	got, err := xiter.CollectErr(records("a", "b", "!", "c"))
+	fmt.Println(got, err)
+	// Output:
+	// [a b] read failed
+}
+
+func ExampleCollectAllErr() {
+	got, err := xiter.CollectAllErr(records("a", "!", "c", "!"))
+	fmt.Println(got)
+	fmt.Println(err)
+	// Output:
+	// [a c]
+	// read failed
+	// read failed
+}
+
+func ExampleSkipErrors() {
+	for s := range xiter.SkipErrors(records("a", "!", "c")) {
+		fmt.Println(s)
+	}
+	// Output:
+	// a
+	// c
+}
+
+// ============================================================================
+// Merge
+// ============================================================================
+
+func ExampleMergeSortedFunc2() {
+	a := slices.All([]string{"a0", "a1"})
+	b := slices.All([]string{"b0", "b1", "b2"})
+	for k, v := range xiter.MergeSortedFunc2(cmp.Compare[int], a, b) {
+		fmt.Printf("%d:%s\n", k, v)
+	}
+	// Output:
+	// 0:a0
+	// 0:b0
+	// 1:a1
+	// 1:b1
+	// 2:b2
+}
@@ -1,8 +1,17 @@
 package xiter_test
 
 import (
+	"cmp"
+	"context"
+	"encoding/binary"
 	"fmt"
+	"io"
 	"iter"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strconv"
 
 	"github.com/go-board/xiter"
 )
@@ -289,6 +298,28 @@ func ExampleFilterMap() {
 	// 8
 }
 
+func ExampleDistinct() {
+	src := slices.Values([]int{3, 1, 3, 2, 1})
+	fmt.Println(slices.Collect(xiter.Distinct(src)))
+	// Output:
+	// [3 1 2]
+}
+
+func ExampleDistinctBy() {
+	src := slices.Values([]string{"apple", "avocado", "banana", "blueberry"})
+	firstLetter := func(s string) byte { return s[0] }
+	fmt.Println(slices.Collect(xiter.DistinctBy(src, firstLetter)))
+	// Output:
+	// [apple banana]
+}
+
+func ExampleDedup() {
+	src := slices.Values([]int{1, 1, 2, 2, 2, 1})
+	fmt.Println(slices.Collect(xiter.Dedup(src)))
+	// Output:
+	// [1 2 1]
+}
+
 func ExampleTake() {
 	for v := range xiter.Take(xiter.Range1(10), 3) {
 		fmt.Println(v)
@@ -341,6 +372,77 @@ func ExampleStepBy() {
 	// 9
 }
 
+func ExampleCartesianPair() {
+	for n, s := range xiter.CartesianPair([]int{1, 2}, []string{"a", "b"}) {
+		fmt.Println(n, s)
+	}
+	// Output:
+	// 1 a
+	// 1 b
+	// 2 a
+	// 2 b
+}
+
+func ExampleCombinations() {
+	for c := range xiter.Combinations([]string{"a", "b", "c"}, 2) {
+		fmt.Println(c)
+	}
+	// Output:
+	// [a b]
+	// [a c]
+	// [b c]
+}
+
+func ExamplePermutations() {
+	first := xiter.Take(xiter.Permutations([]int{1, 2, 3, 4}, 4), 3)
+	fmt.Println(slices.Collect(first))
+	// Output:
+	// [[1 2 3 4] [1 2 4 3] [1 3 2 4]]
+}
+
+func ExamplePowerSet() {
+	fmt.Println(slices.Collect(xiter.PowerSet([]string{"x", "y"})))
+	// Output:
+	// [[] [x] [y] [x y]]
+}
+
+func ExampleChunks() {
+	for c := range xiter.Chunks(xiter.Range1(7), 3) {
+		fmt.Println(c)
+	}
+	// Output:
+	// [0 1 2]
+	// [3 4 5]
+	// [6]
+}
+
+func ExampleWindows() {
+	for w := range xiter.Windows(xiter.Range1(5), 3, 1) {
+		fmt.Println(w)
+	}
+	// Output:
+	// [0 1 2]
+	// [1 2 3]
+	// [2 3 4]
+}
+
+func ExampleChunkBy() {
+	src := func(yield func(int) bool) {
+		for _, v := range []int{1, 2, 5, 3, 4, 0} {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+	for c := range xiter.ChunkBy(src, func(prev, cur int) bool { return prev < cur }) {
+		fmt.Println(c)
+	}
+	// Output:
+	// [1 2 5]
+	// [3 4]
+	// [0]
+}
+
 func ExampleChain() {
 	for v := range xiter.Chain(xiter.Range1(2), xiter.Range2(10, 12)) {
 		fmt.Println(v)
@@ -352,6 +454,36 @@ func ExampleChain() {
 	// 11
 }
 
+func ExampleConcat() {
+	fmt.Println(slices.Collect(xiter.Concat(xiter.Range1(2), xiter.Once(5), xiter.Range2(10, 12))))
+	// Output:
+	// [0 1 5 10 11]
+}
+
+func ExampleInterleave() {
+	high := slices.Values([]string{"h1", "h2", "h3"})
+	low := slices.Values([]string{"l1"})
+	fmt.Println(slices.Collect(xiter.Interleave(high, low)))
+	// Output:
+	// [h1 l1 h2 h3]
+}
+
+func ExampleInterleaveShortest() {
+	a := slices.Values([]int{1, 2, 3})
+	b := slices.Values([]int{10})
+	c := slices.Values([]int{20, 21})
+	fmt.Println(slices.Collect(xiter.InterleaveShortest(a, b, c)))
+	// Output:
+	// [1 10 20]
+}
+
+func ExampleIntersperse() {
+	tokens := xiter.Intersperse(slices.Values([]string{"a", "b", "c"}), "+")
+	fmt.Println(slices.Collect(tokens))
+	// Output:
+	// [a + b + c]
+}
+
 func ExampleZip() {
 	for a, b := range xiter.Zip(xiter.Range1(5), xiter.Range2(10, 13)) {
 		fmt.Printf("%d,%d\n", a, b)
@@ -670,3 +802,388 @@ func ExampleIsSortedFunc() {
 	// true
 	// true
 }
+
+// ============================================================================
+// Context
+// ============================================================================
+
+func ExampleWithContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for v := range xiter.WithContext(ctx, xiter.Range1(10)) {
+		fmt.Println(v)
+		if v == 2 {
+			cancel()
+		}
+	}
+	// Output:
+	// 0
+	// 1
+	// 2
+}
+
+func ExampleForEachCtx() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := xiter.ForEachCtx(ctx, xiter.Repeat(1), func(v int) {
+		fmt.Println(v)
+		cancel()
+	})
+	fmt.Println(err)
+	// Output:
+	// 1
+	// context canceled
+}
+
+func ExampleTryForEachCtx() {
+	err := xiter.TryForEachCtx(context.Background(), xiter.Range1(3), func(v int) error {
+		fmt.Println(v)
+		return nil
+	})
+	fmt.Println(err)
+	// Output:
+	// 0
+	// 1
+	// 2
+	// <nil>
+}
+
+func ExampleTryFoldCtx() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sum, err := xiter.TryFoldCtx(ctx, xiter.Range1(100), 0, func(acc, v int) (int, error) {
+		if v == 4 {
+			cancel()
+		}
+		return acc + v, nil
+	})
+	fmt.Println(sum, err)
+	// Output:
+	// 10 context canceled
+}
+
+// ============================================================================
+// Parallel
+// ============================================================================
+
+func ExampleParallelMap() {
+	squares := xiter.ParallelMap(xiter.Range1(5), 4, func(x int) int { return x * x })
+	for v := range squares {
+		fmt.Println(v)
+	}
+	// Output:
+	// 0
+	// 1
+	// 4
+	// 9
+	// 16
+}
+
+func ExampleTryParallelMap() {
+	src := func(yield func(string) bool) {
+		for _, s := range []string{"1", "2", "x", "4"} {
+			if !yield(s) {
+				return
+			}
+		}
+	}
+	for v, err := range xiter.TryParallelMap(src, 2, strconv.Atoi) {
+		fmt.Println(v, err)
+	}
+	// Output:
+	// 1 <nil>
+	// 2 <nil>
+	// 0 strconv.Atoi: parsing "x": invalid syntax
+}
+
+// ============================================================================
+// Merge
+// ============================================================================
+
+func ExampleMergeSorted() {
+	shard := func(vs ...int) iter.Seq[int] {
+		return func(yield func(int) bool) {
+			for _, v := range vs {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+	for v := range xiter.MergeSorted(shard(1, 4, 7), shard(2, 5), shard(3, 6)) {
+		fmt.Print(v, " ")
+	}
+	fmt.Println()
+	// Output:
+	// 1 2 3 4 5 6 7
+}
+
+func ExampleDifferenceSorted() {
+	yesterday := slices.Values([]int{101, 102, 105, 107})
+	today := slices.Values([]int{101, 103, 105, 107, 108})
+	for id := range xiter.DifferenceSorted(yesterday, today) {
+		fmt.Println("removed", id)
+	}
+	for id := range xiter.DifferenceSorted(today, yesterday) {
+		fmt.Println("added", id)
+	}
+	// Output:
+	// removed 102
+	// added 103
+	// added 108
+}
+
+func ExampleIntersectSorted() {
+	a := slices.Values([]int{1, 3, 5, 7})
+	b := slices.Values([]int{3, 4, 5, 6})
+	fmt.Println(slices.Collect(xiter.IntersectSorted(a, b)))
+	// Output:
+	// [3 5]
+}
+
+// ============================================================================
+// Tee
+// ============================================================================
+
+func ExampleTee() {
+	n := 0
+	readings := xiter.FromFunc(func() (int, bool) {
+		n++
+		return n * 10, n <= 4
+	})
+	r := xiter.Tee(readings, 2)
+	fmt.Println("sum:", xiter.Fold(r[0], 0, func(acc, v int) int { return acc + v }))
+	fmt.Println("max:", xiter.Fold(r[1], 0, func(acc, v int) int { return max(acc, v) }))
+	// Output:
+	// sum: 100
+	// max: 40
+}
+
+func ExampleTryTee() {
+	r := xiter.TryTee(xiter.Range1(10), 2, 3)
+	for v, err := range r[0] {
+		if err != nil {
+			fmt.Println(err)
+			break
+		}
+		fmt.Println(v)
+	}
+	// Output:
+	// 0
+	// 1
+	// 2
+	// xiter: tee reader exceeded the buffer limit
+}
+
+// ============================================================================
+// Memoize
+// ============================================================================
+
+func ExampleMemoize() {
+	calls := 0
+	s, stop := xiter.Memoize(xiter.FromFunc(func() (int, bool) {
+		calls++
+		return calls, calls <= 3
+	}))
+	defer stop()
+	fmt.Println(xiter.Size(s), slices.Collect(s), calls)
+	// Output:
+	// 3 [1 2 3] 4
+}
+
+// ============================================================================
+// Peekable
+// ============================================================================
+
+func ExamplePeekable() {
+	// Group runs of digits into numbers, keeping other characters as-is.
+	isDigit := func(r rune) bool { return '0' <= r && r <= '9' }
+	p := xiter.NewPeekable(slices.Values([]rune("12+345*6")))
+	defer p.Stop()
+	for {
+		r, ok := p.Next()
+		if !ok {
+			break
+		}
+		tok := string(r)
+		if isDigit(r) {
+			for d, ok := p.NextIf(isDigit); ok; d, ok = p.NextIf(isDigit) {
+				tok += string(d)
+			}
+		}
+		fmt.Printf("%q ", tok)
+	}
+	fmt.Println()
+	// Output:
+	// "12" "+" "345" "*" "6"
+}
+
+func ExampleToCursor() {
+	c := xiter.ToCursor(slices.Values([]string{"a", "b", "c"}))
+	defer c.Close()
+	for c.Next() {
+		fmt.Print(c.Value(), " ")
+	}
+	fmt.Println(c.Err())
+	// Output:
+	// a b c <nil>
+}
+
+func ExampleToChan() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := xiter.ToChan(ctx, xiter.Range1(5), 2)
+	for v := range xiter.FromChan(ctx, ch) {
+		fmt.Print(v, " ")
+	}
+	fmt.Println()
+	// Output:
+	// 0 1 2 3 4
+}
+
+func ExampleBuffered() {
+	squares := xiter.Map(xiter.Range1(5), func(x int) int { return x * x })
+	fmt.Println(slices.Collect(xiter.Buffered(squares, 2)))
+	// Output:
+	// [0 1 4 9 16]
+}
+
+// ============================================================================
+// Statistics
+// ============================================================================
+
+func ExampleSum() {
+	fmt.Println(xiter.Sum(xiter.Range2(1, 11)))
+	// Naive summation would cancel the 1 away.
+	fmt.Println(xiter.Sum(slices.Values([]float64{1e100, 1, -1e100})))
+	// Output:
+	// 55
+	// 1
+}
+
+func ExampleSumFunc() {
+	words := slices.Values([]string{"lazy", "iter", "sequences"})
+	fmt.Println(xiter.SumFunc(words, func(w string) int { return len(w) }))
+	// Output:
+	// 17
+}
+
+func ExampleMean() {
+	latencies := slices.Values([]float64{12, 15, 11, 14, 18})
+	mean, _ := xiter.Mean(latencies)
+	sd, _ := xiter.StdDev(latencies)
+	fmt.Printf("%.1f ± %.2f\n", mean, sd)
+	// Output:
+	// 14.0 ± 2.74
+}
+
+func ExampleQuantiles() {
+	qs, _ := xiter.Quantiles(xiter.Range2(1, 101), 0.5, 0.9, 0.99)
+	fmt.Printf("%.2f\n", qs)
+	// Output:
+	// [50.50 90.10 99.01]
+}
+
+func ExampleSketch() {
+	sk := xiter.NewSketch(0)
+	for v := range xiter.Range2(1, 1_000_001) {
+		sk.Add(float64(v))
+	}
+	// Estimates land within about 0.85% of n of the true rank.
+	p50, p99 := sk.Quantile(0.5), sk.Quantile(0.99)
+	fmt.Println(math.Abs(p50-500_000) < 8500, math.Abs(p99-990_000) < 8500, sk.Quantile(1))
+	// Output:
+	// true true 1e+06
+}
+
+// ============================================================================
+// Top-k
+// ============================================================================
+
+func ExampleTopK() {
+	fmt.Println(xiter.TopK(slices.Values([]int{3, 1, 4, 1, 5, 9, 2, 6}), 3, cmp.Compare[int]))
+	// Output:
+	// [9 6 5]
+}
+
+func ExampleTopKBy() {
+	hits := map[string]int{"/login": 40, "/search": 310, "/home": 125, "/about": 3}
+	fmt.Println(xiter.TopKBy(maps.Keys(hits), 2, func(p string) int { return hits[p] }))
+	// Output:
+	// [/search /home]
+}
+
+// ============================================================================
+// Sampling
+// ============================================================================
+
+func ExampleSample() {
+	rng := rand.New(rand.NewPCG(1, 2))
+	sample := xiter.Sample(xiter.Range1(1_000_000), 5, rng)
+	// A generator with the same seed reproduces the same sample.
+	again := xiter.Sample(xiter.Range1(1_000_000), 5, rand.New(rand.NewPCG(1, 2)))
+	fmt.Println(len(sample), slices.Equal(sample, again))
+	// Output:
+	// 5 true
+}
+
+func ExampleBernoulli() {
+	rng := rand.New(rand.NewPCG(1, 2))
+	kept := xiter.Size(xiter.Bernoulli(xiter.Range1(100_000), 0.01, rng))
+	fmt.Println(kept > 800 && kept < 1200)
+	// Output:
+	// true
+}
+
+func ExampleSampleWeighted() {
+	rng := rand.New(rand.NewPCG(1, 2))
+	weight := map[string]float64{"common": 100, "rare": 1, "never": 0}
+	seen := map[string]int{}
+	for range 1000 {
+		for _, v := range xiter.SampleWeighted(maps.Keys(weight), 1, func(s string) float64 { return weight[s] }, rng) {
+			seen[v]++
+		}
+	}
+	fmt.Println(seen["common"] > 950, seen["never"])
+	// Output:
+	// true 0
+}
+
+// ============================================================================
+// Sorting
+// ============================================================================
+
+func ExampleSorted() {
+	fmt.Println(slices.Collect(xiter.Sorted(slices.Values([]int{3, 1, 4, 1, 5}))))
+	// Output:
+	// [1 1 3 4 5]
+}
+
+func ExampleSortedBy() {
+	words := slices.Values([]string{"ccc", "a", "bb", "z"})
+	fmt.Println(slices.Collect(xiter.SortedBy(words, func(w string) int { return len(w) })))
+	// Output:
+	// [a z bb ccc]
+}
+
+// varintCodec stores int64 values as varints for SortedExternal.
+type varintCodec struct{}
+
+func (varintCodec) Encode(w io.Writer, v int64) error {
+	_, err := w.Write(binary.AppendVarint(nil, v))
+	return err
+}
+
+func (varintCodec) Decode(r io.Reader) (int64, error) {
+	return binary.ReadVarint(r.(io.ByteReader))
+}
+
+func ExampleSortedExternal() {
+	values := slices.Values([]int64{42, 7, 19, 3, 88, 7, 61})
+	// A tiny run size forces spilling to disk; real jobs use far larger runs.
+	sorted := xiter.SortedExternal(values, cmp.Compare[int64], varintCodec{}, xiter.RunSize(3))
+	got, err := xiter.CollectErr(sorted)
+	fmt.Println(got, err)
+	// Output:
+	// [3 7 7 19 42 61 88] <nil>
+}
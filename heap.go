@@ -0,0 +1,71 @@
+package xiter
+
+// binaryHeap is a minimal binary min-heap ordered by less. It backs the
+// k-way merge of MergeSortedFunc, and through it the merge of sorted runs in
+// SortedExternal, as well as the bounded selection of the TopK family and
+// SampleWeighted, which need direct access to the root without the interface
+// boxing of container/heap.
+type binaryHeap[E any] struct {
+	data []E
+	less func(a, b E) bool
+}
+
+func (h *binaryHeap[E]) len() int { return len(h.data) }
+
+// top returns the minimum element. The heap must not be empty.
+func (h *binaryHeap[E]) top() E { return h.data[0] }
+
+func (h *binaryHeap[E]) push(e E) {
+	h.data = append(h.data, e)
+	h.up(len(h.data) - 1)
+}
+
+// pop removes and returns the minimum element. The heap must not be empty.
+func (h *binaryHeap[E]) pop() E {
+	n := len(h.data) - 1
+	top := h.data[0]
+	h.data[0] = h.data[n]
+	var zero E
+	h.data[n] = zero
+	h.data = h.data[:n]
+	if n > 0 {
+		h.down(0)
+	}
+	return top
+}
+
+// replaceTop overwrites the minimum element with e and restores the heap
+// order. It is cheaper than a pop followed by a push.
+func (h *binaryHeap[E]) replaceTop(e E) {
+	h.data[0] = e
+	h.down(0)
+}
+
+func (h *binaryHeap[E]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.data[i], h.data[parent]) {
+			return
+		}
+		h.data[i], h.data[parent] = h.data[parent], h.data[i]
+		i = parent
+	}
+}
+
+func (h *binaryHeap[E]) down(i int) {
+	n := len(h.data)
+	for {
+		smallest := i
+		if l := 2*i + 1; l < n && h.less(h.data[l], h.data[smallest]) {
+			smallest = l
+		}
+		if r := 2*i + 2; r < n && h.less(h.data[r], h.data[smallest]) {
+			smallest = r
+		}
+		if smallest == i {
+			return
+		}
+		h.data[i], h.data[smallest] = h.data[smallest], h.data[i]
+		i = smallest
+	}
+}
@@ -0,0 +1,31 @@
+package xiter
+
+import "container/list"
+
+// lruSet is a set that remembers at most size keys, evicting the least
+// recently seen key when full. It backs the bounded-memory Distinct variants.
+type lruSet[K comparable] struct {
+	size  int
+	order *list.List // most recently seen key at the front
+	index map[K]*list.Element
+}
+
+func newLRUSet[K comparable](size int) *lruSet[K] {
+	return &lruSet[K]{size: size, order: list.New(), index: make(map[K]*list.Element)}
+}
+
+// seen reports whether k is currently remembered, then marks k as the most
+// recently seen key, evicting the oldest key if the set is over capacity.
+func (s *lruSet[K]) seen(k K) bool {
+	if e, ok := s.index[k]; ok {
+		s.order.MoveToFront(e)
+		return true
+	}
+	s.index[k] = s.order.PushFront(k)
+	if s.order.Len() > s.size {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.index, oldest.Value.(K))
+	}
+	return false
+}
@@ -0,0 +1,227 @@
+package xiter
+
+import (
+	"errors"
+	"iter"
+	"sync"
+)
+
+// ============================================================================
+// Memoize
+// ============================================================================
+
+// ErrMemoizeLimit is yielded by an iteration of a TryMemoizeLimit sequence
+// that needs an element past the recording limit after another iteration has
+// already taken over the source, so that the element can no longer be
+// produced.
+var ErrMemoizeLimit = errors.New("xiter: memoized sequence iterated past its recording limit")
+
+// Memoize returns a sequence that iterates s at most once and replays it
+// afterwards, together with a function that releases s. The first iteration
+// pulls from s and records every element; later iterations replay the
+// recorded prefix and, if s has not been exhausted yet, continue pulling from
+// where the source left off. It makes pipelines built on side-effecting
+// sources such as FromFunc or Iterate safe to iterate several times, e.g.
+// Size followed by ForEach.
+//
+// The returned sequence may be iterated by several goroutines at once; each
+// source element is still produced exactly once. The recording is unbounded;
+// use TryMemoizeLimit to bound memory.
+//
+// Because a later iteration may continue where an earlier one stopped, s is
+// not stopped when an iteration breaks early: it stays suspended until it is
+// exhausted or stop is called. Call stop, typically with defer, once the
+// memoized sequence is no longer needed. After stop, iterations replay the
+// recorded elements and then end. stop is idempotent and may be called
+// concurrently with iterations.
+//
+//	n := 0
+//	s, stop := Memoize(FromFunc(func() (int, bool) { n++; return n, n <= 3 }))
+//	defer stop()
+//	Size(s)  // 3
+//	Size(s)  // 3, and the supplier is not called again
+func Memoize[E any](s iter.Seq[E]) (iter.Seq[E], func()) {
+	m := newMemo(s, 0)
+	return func(yield func(E) bool) {
+		m.iterate(yield) // never fails without a limit
+	}, m.release
+}
+
+// TryMemoizeLimit is like Memoize but records at most limit elements. The
+// first iteration that needs element limit+1 takes over the source: it
+// yields the rest of s straight from the source without recording it, so
+// that one pass sees every element even when s is single-use, and releases s
+// when it ends. Any other iteration that needs an element past the recorded
+// prefix, whether concurrent or later, yields a zero value together with
+// ErrMemoizeLimit and stops. All other elements are yielded as (value, nil)
+// pairs. When limit <= 0 the recording is unbounded and no error is ever
+// yielded. As with Memoize, call stop to release s early.
+//
+//	m, stop := TryMemoizeLimit(FromFunc(f), 3)
+//	defer stop()
+//	CollectErr(m)  // every element of the source, nil
+//	CollectErr(m)  // the 3 recorded elements, ErrMemoizeLimit
+func TryMemoizeLimit[E any](s iter.Seq[E], limit int) (iter.Seq2[E, error], func()) {
+	m := newMemo(s, limit)
+	return func(yield func(E, error) bool) {
+		err := m.iterate(func(e E) bool { return yield(e, nil) })
+		if err != nil {
+			var zero E
+			yield(zero, err)
+		}
+	}, m.release
+}
+
+// Memoize2 is the iter.Seq2 variant of Memoize: it iterates the key/value
+// sequence s at most once and replays the recorded pairs afterwards. Call
+// stop to release s.
+func Memoize2[K, V any](s iter.Seq2[K, V]) (iter.Seq2[K, V], func()) {
+	m, stop := Memoize(zipPairs(s))
+	return func(yield func(K, V) bool) {
+		for p := range m {
+			if !yield(p.k, p.v) {
+				return
+			}
+		}
+	}, stop
+}
+
+// memo is the recording shared by every iteration of a memoized sequence.
+type memo[E any] struct {
+	mu   sync.Mutex
+	cond sync.Cond
+
+	src  iter.Seq[E]
+	pull func() (E, bool) // set by the first pull
+	stop func()
+
+	buf      []E
+	limit    int
+	pulling  bool // an iteration is pulling from the source outside mu
+	ended    bool // the source is exhausted
+	overflow bool // the limit was reached and an iteration took over the source
+	released bool // the source was released before it was exhausted
+}
+
+func newMemo[E any](s iter.Seq[E], limit int) *memo[E] {
+	m := &memo[E]{src: s, limit: limit}
+	m.cond.L = &m.mu
+	return m
+}
+
+// iterate yields the elements of the source in order, replaying the recorded
+// prefix and recording new elements up to the limit. If it takes over the
+// source past the limit, it pulls the remaining elements itself and releases
+// the source when it returns. It returns ErrMemoizeLimit when it needs an
+// element past the limit after another iteration took over.
+func (m *memo[E]) iterate(yield func(E) bool) error {
+	for i := 0; ; i++ {
+		e, ok, owner, err := m.at(i)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		if owner {
+			defer m.release()
+			for ok {
+				if !yield(e) {
+					return nil
+				}
+				e, ok = m.next()
+			}
+			return nil
+		}
+		if !yield(e) {
+			return nil
+		}
+	}
+}
+
+// at returns element i of the source, recording it first if needed. ok is
+// false once the source is exhausted or released before i. When i is the
+// first element past the limit, at pulls it without recording it and reports
+// owner: the caller has taken over the source and must pull the rest with
+// next and release it. Later calls for elements past the limit return
+// ErrMemoizeLimit.
+func (m *memo[E]) at(i int) (e E, ok, owner bool, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for {
+		if i < len(m.buf) {
+			return m.buf[i], true, false, nil
+		}
+		switch {
+		case m.ended:
+			return e, false, false, nil
+		case m.overflow:
+			return e, false, false, ErrMemoizeLimit
+		case m.released:
+			return e, false, false, nil
+		case m.pulling:
+			m.cond.Wait()
+			continue
+		}
+		v, more := m.pullUnlocked()
+		switch {
+		case !more:
+			m.ended = true
+			m.stop()
+		case m.limit > 0 && len(m.buf) >= m.limit:
+			m.overflow = true
+			return v, true, true, nil
+		default:
+			m.buf = append(m.buf, v)
+		}
+	}
+}
+
+// next pulls the next element for the iteration that took over the source,
+// without recording it. It reports false once the source is exhausted or
+// released.
+func (m *memo[E]) next() (e E, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.released {
+		return e, false
+	}
+	return m.pullUnlocked()
+}
+
+// release stops the source, first waiting for a pull in progress to finish.
+// It is idempotent. Iterations that have not finished yet end after the
+// recorded prefix.
+func (m *memo[E]) release() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for m.pulling {
+		m.cond.Wait()
+	}
+	if m.released {
+		return
+	}
+	m.released = true
+	if m.stop != nil {
+		m.stop()
+	}
+	m.cond.Broadcast()
+}
+
+// pullUnlocked pulls the next element from the source with mu released, so
+// that other iterations can keep replaying while the source produces it. It
+// must be called with mu held and returns with mu held, waking the iterations
+// waiting for the pull.
+func (m *memo[E]) pullUnlocked() (E, bool) {
+	if m.pull == nil {
+		m.pull, m.stop = iter.Pull(m.src)
+	}
+	m.pulling = true
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		m.pulling = false
+		m.cond.Broadcast()
+	}()
+	return m.pull()
+}
@@ -0,0 +1,196 @@
+package xiter
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// counter returns a single-use supplier of 1..n and the number of elements it
+// has produced so far.
+func counter(n int) (func() (int, bool), *int) {
+	calls := 0
+	return func() (int, bool) {
+		if calls >= n {
+			return 0, false
+		}
+		calls++
+		return calls, true
+	}, &calls
+}
+
+func TestMemoize(t *testing.T) {
+	f, calls := counter(5)
+	s, stop := Memoize(FromFunc(f))
+	defer stop()
+	if n := Size(s); n != 5 {
+		t.Fatalf("got size %d, want 5", n)
+	}
+	if got := ToSlice(s); !reflect.DeepEqual(got, []int{1, 2, 3, 4, 5}) {
+		t.Fatalf("got %v, want [1 2 3 4 5]", got)
+	}
+	if *calls != 5 {
+		t.Fatalf("source produced %d elements, want 5", *calls)
+	}
+	stopEarly(s)
+}
+
+func TestMemoizeResume(t *testing.T) {
+	f, calls := counter(5)
+	s, stop := Memoize(FromFunc(f))
+	defer stop()
+	if got := ToSlice(Take(s, 2)); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("got %v, want [1 2]", got)
+	}
+	if *calls != 2 {
+		t.Fatalf("source produced %d elements after Take, want 2", *calls)
+	}
+	if got := ToSlice(s); !reflect.DeepEqual(got, []int{1, 2, 3, 4, 5}) {
+		t.Fatalf("got %v, want [1 2 3 4 5]", got)
+	}
+	if *calls != 5 {
+		t.Fatalf("source produced %d elements, want 5", *calls)
+	}
+}
+
+func TestMemoizeConcurrent(t *testing.T) {
+	f, calls := counter(500)
+	s, stop := Memoize(FromFunc(f))
+	defer stop()
+	want := ToSlice(Range2(1, 501))
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := ToSlice(s); !reflect.DeepEqual(got, want) {
+				t.Errorf("got %d elements, want 500 in order", len(got))
+			}
+		}()
+	}
+	wg.Wait()
+	if *calls != 500 {
+		t.Fatalf("source produced %d elements, want 500", *calls)
+	}
+}
+
+func TestTryMemoizeLimit(t *testing.T) {
+	f, calls := counter(10)
+	s, stop := TryMemoizeLimit(FromFunc(f), 3)
+	defer stop()
+	// The pass that runs past the limit streams the rest of the single-use
+	// source without recording it.
+	vs, err := CollectErr(s)
+	if want := ToSlice(Range2(1, 11)); !reflect.DeepEqual(vs, want) || err != nil {
+		t.Fatalf("got %v, %v; want %v, nil", vs, err, want)
+	}
+	if *calls != 10 {
+		t.Fatalf("source produced %d elements, want 10", *calls)
+	}
+	if vs, err := CollectErr(Take2(s, 3)); !reflect.DeepEqual(vs, []int{1, 2, 3}) || err != nil {
+		t.Fatalf("replay got %v, %v; want [1 2 3], nil", vs, err)
+	}
+	// A later pass past the recorded prefix reports the error and stops.
+	vs, err = CollectErr(s)
+	if !reflect.DeepEqual(vs, []int{1, 2, 3}) || err != ErrMemoizeLimit {
+		t.Fatalf("got %v, %v; want [1 2 3], %v", vs, err, ErrMemoizeLimit)
+	}
+	stopEarly2(s)
+}
+
+func TestTryMemoizeLimitExact(t *testing.T) {
+	f, _ := counter(3)
+	s, stop := TryMemoizeLimit(FromFunc(f), 3)
+	defer stop()
+	if vs, _ := CollectErr(Take2(s, 3)); !reflect.DeepEqual(vs, []int{1, 2, 3}) {
+		t.Fatalf("got %v, want [1 2 3]", vs)
+	}
+	// A source that fits the limit is replayed in full by every pass.
+	for range 2 {
+		if vs, err := CollectErr(s); !reflect.DeepEqual(vs, []int{1, 2, 3}) || err != nil {
+			t.Fatalf("got %v, %v; want [1 2 3], nil", vs, err)
+		}
+	}
+}
+
+func TestTryMemoizeLimitConcurrent(t *testing.T) {
+	f, _ := counter(100)
+	s, stop := TryMemoizeLimit(FromFunc(f), 10)
+	defer stop()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	complete, failed := 0, 0
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			vs, err := CollectErr(s)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil && len(vs) == 100:
+				complete++
+			case err == ErrMemoizeLimit && len(vs) == 10:
+				failed++
+			default:
+				t.Errorf("got %d elements, %v", len(vs), err)
+			}
+		}()
+	}
+	wg.Wait()
+	if complete != 1 || failed != 3 {
+		t.Fatalf("%d complete and %d failed passes, want 1 and 3", complete, failed)
+	}
+}
+
+func TestMemoizeStop(t *testing.T) {
+	src, released := onceSource(t, 1, 2, 3)
+	s, stop := Memoize(src)
+	if got := ToSlice(Take(s, 2)); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("got %v, want [1 2]", got)
+	}
+	if *released {
+		t.Fatal("source released before stop, later passes could not resume it")
+	}
+	stop()
+	if !*released {
+		t.Fatal("source not released by stop")
+	}
+	stop()
+	// After stop only the recorded prefix is replayed.
+	if got := ToSlice(s); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("got %v, want [1 2]", got)
+	}
+
+	_, stop = Memoize(Range1(3))
+	stop()
+
+	// The pass that takes over past the limit releases the source when it
+	// stops.
+	src, released = onceSource(t, 1, 2, 3)
+	m, stop := TryMemoizeLimit(src, 1)
+	defer stop()
+	if vs, _ := CollectErr(Take2(m, 2)); !reflect.DeepEqual(vs, []int{1, 2}) || !*released {
+		t.Fatalf("got %v, released %v; want [1 2], true", vs, *released)
+	}
+}
+
+func TestMemoize2(t *testing.T) {
+	calls := 0
+	src := func(yield func(string, int) bool) {
+		calls++
+		_ = yield("a", 1) && yield("b", 2)
+	}
+	s, stop := Memoize2(src)
+	defer stop()
+	want := map[string]int{"a": 1, "b": 2}
+	for range 2 {
+		if got := ToMap(s); !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("source iterated %d times, want 1", calls)
+	}
+	stopEarly2(s)
+}
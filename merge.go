@@ -0,0 +1,223 @@
+package xiter
+
+import (
+	"cmp"
+	"iter"
+)
+
+// ============================================================================
+// Merge
+// ============================================================================
+
+// MergeSorted merges sequences that are each sorted in non-decreasing order
+// according to cmp.Compare into a single sorted sequence. It is a shortcut for
+// MergeSortedFunc with cmp.Compare.
+//
+//	MergeSorted(seqOf(1, 4, 7), seqOf(2, 5), seqOf(3, 6))
+//	// yields 1, 2, 3, 4, 5, 6, 7
+func MergeSorted[E cmp.Ordered](seqs ...iter.Seq[E]) iter.Seq[E] {
+	return MergeSortedFunc(cmp.Compare[E], seqs...)
+}
+
+// MergeSortedFunc lazily merges sequences that are each sorted in
+// non-decreasing order according to cmp into a single sorted sequence. cmp
+// must follow the cmp.Compare convention. Equal elements are yielded in the
+// order of the sequences that produced them, so the merge is stable.
+//
+// Every input is pulled with iter.Pull one element at a time, and a heap of
+// the current heads selects the next element, so merging k inputs holds k
+// elements in memory and costs O(log k) per element. When the consumer breaks
+// early, every input is released. If an input is not sorted, the output is
+// still a permutation of all inputs but is not sorted.
+func MergeSortedFunc[E any](cmp func(E, E) int, seqs ...iter.Seq[E]) iter.Seq[E] {
+	return mergeSorted(seqs, func(e E) E { return e }, cmp)
+}
+
+// MergeSorted2 merges key/value sequences that are each sorted by key in
+// non-decreasing order according to cmp.Compare into a single sequence sorted
+// by key. It is a shortcut for MergeSortedFunc2 with cmp.Compare.
+func MergeSorted2[K cmp.Ordered, V any](seqs ...iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return MergeSortedFunc2(cmp.Compare[K], seqs...)
+}
+
+// MergeSortedFunc2 is the iter.Seq2 variant of MergeSortedFunc: it lazily
+// merges key/value sequences that are each sorted by key according to cmp
+// into a single sequence sorted by key. Values never take part in the
+// comparison; pairs with equal keys are yielded in the order of the sequences
+// that produced them.
+func MergeSortedFunc2[K, V any](cmp func(K, K) int, seqs ...iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		packed := make([]iter.Seq[pair[K, V]], len(seqs))
+		for i, s := range seqs {
+			packed[i] = zipPairs(s)
+		}
+		for p := range mergeSorted(packed, func(p pair[K, V]) K { return p.k }, cmp) {
+			if !yield(p.k, p.v) {
+				return
+			}
+		}
+	}
+}
+
+// mergeHead is the current head of one merge input together with the
+// position of that input, used to keep the merge stable.
+type mergeHead[E any] struct {
+	e    E
+	idx  int
+	next func() (E, bool)
+}
+
+// mergeSorted is the heap-based k-way merge shared by MergeSortedFunc and
+// MergeSortedFunc2. Elements are ordered by cmp applied to key(e).
+func mergeSorted[E, K any](seqs []iter.Seq[E], key func(E) K, cmp func(K, K) int) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		h := binaryHeap[mergeHead[E]]{
+			data: make([]mergeHead[E], 0, len(seqs)),
+			less: func(a, b mergeHead[E]) bool {
+				if c := cmp(key(a.e), key(b.e)); c != 0 {
+					return c < 0
+				}
+				return a.idx < b.idx
+			},
+		}
+		for i, s := range seqs {
+			next, stop := iter.Pull(s)
+			defer stop()
+			if e, ok := next(); ok {
+				h.push(mergeHead[E]{e, i, next})
+			}
+		}
+		for h.len() > 0 {
+			head := h.top()
+			if !yield(head.e) {
+				return
+			}
+			if e, ok := head.next(); ok {
+				head.e = e
+				h.replaceTop(head)
+			} else {
+				h.pop()
+			}
+		}
+	}
+}
+
+// ============================================================================
+// Set operations on sorted sequences
+// ============================================================================
+//
+// The set operations below take two sequences that are each sorted in
+// non-decreasing order and walk them in lockstep with iter.Pull, as EqualFunc
+// does, so they run in O(1) extra space and yield a sorted result. Inputs may
+// contain duplicates; an element that appears m times in x and n times in y
+// is treated as a multiset member, following C++'s std::set_union family.
+
+// UnionSorted yields every element present in x or y, in sorted order. An
+// element appearing m times in x and n times in y is yielded max(m, n) times;
+// of equal elements the one from x is yielded.
+//
+//	UnionSorted(seqOf(1, 3, 5), seqOf(1, 2, 5, 6))  // yields 1, 2, 3, 5, 6
+func UnionSorted[E cmp.Ordered](x, y iter.Seq[E]) iter.Seq[E] {
+	return UnionSortedFunc(x, y, cmp.Compare[E])
+}
+
+// UnionSortedFunc is like UnionSorted but orders elements with f, which must
+// follow the cmp.Compare convention.
+func UnionSortedFunc[E any](x, y iter.Seq[E], f func(E, E) int) iter.Seq[E] {
+	return sortedSetOp(x, y, f, true, true, true)
+}
+
+// IntersectSorted yields the elements present in both x and y, in sorted
+// order. An element appearing m times in x and n times in y is yielded
+// min(m, n) times. Iteration stops as soon as either input is exhausted.
+//
+//	IntersectSorted(seqOf(1, 3, 5), seqOf(1, 2, 5, 6))  // yields 1, 5
+func IntersectSorted[E cmp.Ordered](x, y iter.Seq[E]) iter.Seq[E] {
+	return IntersectSortedFunc(x, y, cmp.Compare[E])
+}
+
+// IntersectSortedFunc is like IntersectSorted but orders elements with f,
+// which must follow the cmp.Compare convention.
+func IntersectSortedFunc[E any](x, y iter.Seq[E], f func(E, E) int) iter.Seq[E] {
+	return sortedSetOp(x, y, f, false, false, true)
+}
+
+// DifferenceSorted yields the elements of x that are not in y, in sorted
+// order. An element appearing m times in x and n times in y is yielded
+// max(m-n, 0) times. Once x is exhausted, y is not consumed further.
+//
+//	DifferenceSorted(seqOf(1, 3, 5), seqOf(1, 2, 5, 6))  // yields 3
+func DifferenceSorted[E cmp.Ordered](x, y iter.Seq[E]) iter.Seq[E] {
+	return DifferenceSortedFunc(x, y, cmp.Compare[E])
+}
+
+// DifferenceSortedFunc is like DifferenceSorted but orders elements with f,
+// which must follow the cmp.Compare convention.
+func DifferenceSortedFunc[E any](x, y iter.Seq[E], f func(E, E) int) iter.Seq[E] {
+	return sortedSetOp(x, y, f, true, false, false)
+}
+
+// SymmetricDifferenceSorted yields the elements present in exactly one of x
+// and y, in sorted order. An element appearing m times in x and n times in y
+// is yielded |m-n| times.
+//
+//	SymmetricDifferenceSorted(seqOf(1, 3, 5), seqOf(1, 2, 5, 6))  // yields 2, 3, 6
+func SymmetricDifferenceSorted[E cmp.Ordered](x, y iter.Seq[E]) iter.Seq[E] {
+	return SymmetricDifferenceSortedFunc(x, y, cmp.Compare[E])
+}
+
+// SymmetricDifferenceSortedFunc is like SymmetricDifferenceSorted but orders
+// elements with f, which must follow the cmp.Compare convention.
+func SymmetricDifferenceSortedFunc[E any](x, y iter.Seq[E], f func(E, E) int) iter.Seq[E] {
+	return sortedSetOp(x, y, f, true, true, false)
+}
+
+// sortedSetOp walks x and y in lockstep and yields the elements that are only
+// in x, only in y, or in both, as selected by the flags.
+func sortedSetOp[E any](x, y iter.Seq[E], f func(E, E) int, onlyX, onlyY, both bool) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		nextX, stopX := iter.Pull(x)
+		defer stopX()
+		ex, okX := nextX()
+		if !okX && !onlyY {
+			return
+		}
+		nextY, stopY := iter.Pull(y)
+		defer stopY()
+		ey, okY := nextY()
+
+		for okX && okY {
+			switch c := f(ex, ey); {
+			case c < 0:
+				if onlyX && !yield(ex) {
+					return
+				}
+				ex, okX = nextX()
+			case c > 0:
+				if onlyY && !yield(ey) {
+					return
+				}
+				ey, okY = nextY()
+			default:
+				if both && !yield(ex) {
+					return
+				}
+				ex, okX = nextX()
+				if !okX && !onlyY {
+					return
+				}
+				ey, okY = nextY()
+			}
+		}
+		for ; okX && onlyX; ex, okX = nextX() {
+			if !yield(ex) {
+				return
+			}
+		}
+		for ; okY && onlyY; ey, okY = nextY() {
+			if !yield(ey) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package xiter
+
+import (
+	"iter"
+	"reflect"
+	"testing"
+)
+
+func TestMergeSorted(t *testing.T) {
+	got := ToSlice(MergeSorted(seqOf(1, 4, 7, 10), seqOf(2, 5), Empty[int](), seqOf(0, 3, 6, 8, 9)))
+	want := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := ToSlice(MergeSorted[int]()); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	if got := ToSlice(MergeSorted(seqOf(3, 1, 2))); !reflect.DeepEqual(got, []int{3, 1, 2}) {
+		t.Fatalf("single input got %v, want it unchanged", got)
+	}
+}
+
+func TestMergeSortedFuncStable(t *testing.T) {
+	type item struct {
+		key int
+		src string
+	}
+	byKey := func(a, b item) int { return a.key - b.key }
+	got := ToSlice(MergeSortedFunc(byKey,
+		seqOf(item{1, "a"}, item{2, "a"}),
+		seqOf(item{1, "b"}, item{2, "b"}),
+		seqOf(item{1, "c"}),
+	))
+	want := []item{{1, "a"}, {1, "b"}, {1, "c"}, {2, "a"}, {2, "b"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	desc := func(a, b int) int { return b - a }
+	if got := ToSlice(MergeSortedFunc(desc, seqOf(9, 5, 1), seqOf(8, 2))); !reflect.DeepEqual(got, []int{9, 8, 5, 2, 1}) {
+		t.Fatalf("descending got %v", got)
+	}
+}
+
+func TestMergeSortedLazyAndReleases(t *testing.T) {
+	var pulledA, pulledB []int
+	stoppedA, stoppedB := false, false
+	src := func(pulled *[]int, stopped *bool, vs ...int) func(func(int) bool) {
+		return func(yield func(int) bool) {
+			for _, v := range vs {
+				*pulled = append(*pulled, v)
+				if !yield(v) {
+					*stopped = true
+					return
+				}
+			}
+		}
+	}
+	got := ToSlice(Take(MergeSorted(
+		src(&pulledA, &stoppedA, 1, 3, 5, 7),
+		src(&pulledB, &stoppedB, 2, 4, 6, 8),
+	), 3))
+	if !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Fatalf("got %v, want [1 2 3]", got)
+	}
+	if !reflect.DeepEqual(pulledA, []int{1, 3}) || !reflect.DeepEqual(pulledB, []int{2, 4}) {
+		t.Fatalf("pulled %v and %v, want [1 3] and [2 4]", pulledA, pulledB)
+	}
+	if !stoppedA || !stoppedB {
+		t.Fatalf("sources not released: %t, %t", stoppedA, stoppedB)
+	}
+	stopEarly(MergeSorted(seqOf(1), seqOf(2)))
+}
+
+func TestMergeSorted2(t *testing.T) {
+	type kv = struct {
+		K int
+		V string
+	}
+	var keys []int
+	var values []string
+	for k, v := range MergeSorted2(
+		seq2Of(kv{1, "a1"}, kv{3, "a3"}),
+		seq2Of(kv{1, "b1"}, kv{2, "b2"}),
+	) {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	if !reflect.DeepEqual(keys, []int{1, 1, 2, 3}) {
+		t.Fatalf("keys got %v", keys)
+	}
+	if !reflect.DeepEqual(values, []string{"a1", "b1", "b2", "a3"}) {
+		t.Fatalf("values got %v", values)
+	}
+	stopEarly2(MergeSortedFunc2(func(a, b int) int { return a - b }, seq2Of(kv{1, "a"})))
+}
+
+func TestSortedSetOperations(t *testing.T) {
+	cases := []struct {
+		name                      string
+		x, y                      []int
+		union, inter, diff, sdiff []int
+	}{
+		{"basic", []int{1, 3, 5}, []int{1, 2, 5, 6}, []int{1, 2, 3, 5, 6}, []int{1, 5}, []int{3}, []int{2, 3, 6}},
+		{"duplicates", []int{1, 1, 2, 2, 2}, []int{1, 2, 3, 3}, []int{1, 1, 2, 2, 2, 3, 3}, []int{1, 2}, []int{1, 2, 2}, []int{1, 2, 2, 3, 3}},
+		{"x empty", nil, []int{1, 2}, []int{1, 2}, nil, nil, []int{1, 2}},
+		{"y empty", []int{1, 2}, nil, []int{1, 2}, nil, []int{1, 2}, []int{1, 2}},
+		{"disjoint", []int{1, 2}, []int{3, 4}, []int{1, 2, 3, 4}, nil, []int{1, 2}, []int{1, 2, 3, 4}},
+		{"equal", []int{1, 2}, []int{1, 2}, []int{1, 2}, []int{1, 2}, nil, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			x, y := seqOf(c.x...), seqOf(c.y...)
+			if got := ToSlice(UnionSorted(x, y)); !reflect.DeepEqual(got, c.union) {
+				t.Errorf("UnionSorted = %v, want %v", got, c.union)
+			}
+			if got := ToSlice(IntersectSorted(x, y)); !reflect.DeepEqual(got, c.inter) {
+				t.Errorf("IntersectSorted = %v, want %v", got, c.inter)
+			}
+			if got := ToSlice(DifferenceSorted(x, y)); !reflect.DeepEqual(got, c.diff) {
+				t.Errorf("DifferenceSorted = %v, want %v", got, c.diff)
+			}
+			if got := ToSlice(SymmetricDifferenceSorted(x, y)); !reflect.DeepEqual(got, c.sdiff) {
+				t.Errorf("SymmetricDifferenceSorted = %v, want %v", got, c.sdiff)
+			}
+		})
+	}
+}
+
+func TestSortedSetOperationsFunc(t *testing.T) {
+	desc := func(a, b int) int { return b - a }
+	x, y := seqOf(9, 7, 5, 3), seqOf(8, 7, 3, 1)
+	if got := ToSlice(UnionSortedFunc(x, y, desc)); !reflect.DeepEqual(got, []int{9, 8, 7, 5, 3, 1}) {
+		t.Errorf("UnionSortedFunc = %v", got)
+	}
+	if got := ToSlice(IntersectSortedFunc(x, y, desc)); !reflect.DeepEqual(got, []int{7, 3}) {
+		t.Errorf("IntersectSortedFunc = %v", got)
+	}
+	if got := ToSlice(DifferenceSortedFunc(x, y, desc)); !reflect.DeepEqual(got, []int{9, 5}) {
+		t.Errorf("DifferenceSortedFunc = %v", got)
+	}
+	if got := ToSlice(SymmetricDifferenceSortedFunc(x, y, desc)); !reflect.DeepEqual(got, []int{9, 8, 5, 1}) {
+		t.Errorf("SymmetricDifferenceSortedFunc = %v", got)
+	}
+}
+
+func TestSortedSetOperationsLazy(t *testing.T) {
+	var pulledY []int
+	y := Inspect(Range1(100), func(v int) { pulledY = append(pulledY, v) })
+	if got := ToSlice(DifferenceSorted(seqOf(1, 2), y)); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	if !reflect.DeepEqual(pulledY, []int{0, 1, 2}) {
+		t.Fatalf("pulled %v from y, want [0 1 2]", pulledY)
+	}
+
+	pulledY = nil
+	if got := ToSlice(IntersectSorted(Empty[int](), y)); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	if len(pulledY) != 0 {
+		t.Fatalf("pulled %v from y, want nothing", pulledY)
+	}
+
+	for _, s := range []func(x, y iter.Seq[int]) iter.Seq[int]{
+		UnionSorted[int], IntersectSorted[int], DifferenceSorted[int], SymmetricDifferenceSorted[int],
+	} {
+		stopEarly(s(seqOf(1, 2), seqOf(2, 3)))
+		stopEarly(s(seqOf(1), seqOf(0)))
+		stopEarly(s(seqOf(1, 2), Empty[int]()))
+		stopEarly(s(Empty[int](), seqOf(1, 2)))
+	}
+}
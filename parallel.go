@@ -0,0 +1,168 @@
+package xiter
+
+import (
+	"iter"
+	"runtime"
+	"sync"
+)
+
+// ============================================================================
+// Parallel
+// ============================================================================
+
+// ParallelMap applies f to each element on a pool of at most workers
+// goroutines and yields the results in source order. When workers <= 0,
+// runtime.GOMAXPROCS(0) workers are used.
+//
+// The source is pulled on the consumer's goroutine and at most workers
+// elements are in flight at any time, so a slow head element holds back the
+// results behind it. When the consumer breaks early, no new elements are
+// pulled, in-flight calls to f are allowed to finish and their results are
+// discarded, and every worker goroutine has exited before iteration returns.
+// A panic in f is re-raised on the consumer's goroutine when its result is
+// reached.
+//
+//	ParallelMap(Range1(5), 4, func(x int) int { return x * x })
+//	// yields 0, 1, 4, 9, 16
+func ParallelMap[E1, E2 any](s iter.Seq[E1], workers int, f func(E1) E2) iter.Seq[E2] {
+	return parallelMap(s, workers, true, f)
+}
+
+// ParallelMapUnordered is like ParallelMap but yields each result as soon as
+// it is ready, regardless of source order. It avoids head-of-line blocking
+// when the cost of f varies between elements.
+func ParallelMapUnordered[E1, E2 any](s iter.Seq[E1], workers int, f func(E1) E2) iter.Seq[E2] {
+	return parallelMap(s, workers, false, f)
+}
+
+// ParallelMap2 is the iter.Seq2 variant of ParallelMap: it applies f to each
+// key/value pair on a pool of at most workers goroutines and yields the
+// resulting pairs in source order.
+func ParallelMap2[K1, V1, K2, V2 any](s iter.Seq2[K1, V1], workers int, f func(K1, V1) (K2, V2)) iter.Seq2[K2, V2] {
+	return func(yield func(K2, V2) bool) {
+		packed := Join(s, func(k K1, v V1) pair[K1, V1] { return pair[K1, V1]{k, v} })
+		mapped := parallelMap(packed, workers, true, func(p pair[K1, V1]) pair[K2, V2] {
+			k, v := f(p.k, p.v)
+			return pair[K2, V2]{k, v}
+		})
+		for p := range mapped {
+			if !yield(p.k, p.v) {
+				return
+			}
+		}
+	}
+}
+
+// TryParallelMap is like ParallelMap for a fallible f. Results are yielded in
+// source order as (value, nil) pairs. The first error in source order is
+// yielded together with the value f returned alongside it, after which the
+// sequence stops: no further elements are pulled and in-flight calls are
+// drained before iteration returns.
+//
+//	TryParallelMap(seqOf("1", "x", "3"), 2, strconv.Atoi)
+//	// yields (1,nil), (0,error)
+func TryParallelMap[E1, E2 any](s iter.Seq[E1], workers int, f func(E1) (E2, error)) iter.Seq2[E2, error] {
+	return func(yield func(E2, error) bool) {
+		mapped := parallelMap(s, workers, true, func(e E1) pair[E2, error] {
+			v, err := f(e)
+			return pair[E2, error]{v, err}
+		})
+		for p := range mapped {
+			if !yield(p.k, p.v) || p.v != nil {
+				return
+			}
+		}
+	}
+}
+
+// parallelJob is a unit of work handed to a ParallelMap worker. out receives
+// exactly one result and is buffered so that workers never block on it.
+type parallelJob[E1, E2 any] struct {
+	e   E1
+	out chan parallelResult[E2]
+}
+
+// parallelResult is the outcome of one call to f, or the value recovered
+// from its panic.
+type parallelResult[E any] struct {
+	v        E
+	panicked bool
+	p        any
+}
+
+func parallelMap[E1, E2 any](s iter.Seq[E1], workers int, ordered bool, f func(E1) E2) iter.Seq[E2] {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return func(yield func(E2) bool) {
+		next, stop := iter.Pull(s)
+		defer stop()
+
+		jobs := make(chan parallelJob[E1, E2], workers)
+		var wg sync.WaitGroup
+		wg.Add(workers)
+		for range workers {
+			go func() {
+				defer wg.Done()
+				for j := range jobs {
+					j.out <- callRecover(f, j.e)
+				}
+			}()
+		}
+		defer func() {
+			close(jobs)
+			wg.Wait()
+		}()
+
+		// In unordered mode every job shares one channel; in ordered mode each
+		// job gets its own and queue keeps them in source order.
+		shared := make(chan parallelResult[E2], workers)
+		var queue []chan parallelResult[E2]
+		pending := 0
+		exhausted := false
+		for {
+			for pending < workers && !exhausted {
+				e, ok := next()
+				if !ok {
+					exhausted = true
+					break
+				}
+				out := shared
+				if ordered {
+					out = make(chan parallelResult[E2], 1)
+					queue = append(queue, out)
+				}
+				jobs <- parallelJob[E1, E2]{e, out}
+				pending++
+			}
+			if pending == 0 {
+				return
+			}
+			var r parallelResult[E2]
+			if ordered {
+				r = <-queue[0]
+				queue = queue[1:]
+			} else {
+				r = <-shared
+			}
+			pending--
+			if r.panicked {
+				panic(r.p)
+			}
+			if !yield(r.v) {
+				return
+			}
+		}
+	}
+}
+
+// callRecover calls f(e) and captures a panic instead of letting it crash a
+// worker goroutine.
+func callRecover[E1, E2 any](f func(E1) E2, e E1) (r parallelResult[E2]) {
+	defer func() {
+		if p := recover(); p != nil {
+			r = parallelResult[E2]{panicked: true, p: p}
+		}
+	}()
+	return parallelResult[E2]{v: f(e)}
+}
@@ -0,0 +1,167 @@
+package xiter
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelMap(t *testing.T) {
+	got := ToSlice(ParallelMap(Range1(50), 4, func(x int) int {
+		// Later elements finish first; results must still come out in order.
+		time.Sleep(time.Duration(50-x) * 20 * time.Microsecond)
+		return x * 2
+	}))
+	want := ToSlice(Map(Range1(50), func(x int) int { return x * 2 }))
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := ToSlice(ParallelMap(Empty[int](), 4, func(x int) int { return x })); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	if got := ToSlice(ParallelMap(Range1(3), 0, func(x int) int { return x })); !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Fatalf("workers=0: got %v, want [0 1 2]", got)
+	}
+}
+
+func TestParallelMapBoundsWorkers(t *testing.T) {
+	var active, peak atomic.Int32
+	ForEach(ParallelMap(Range1(40), 3, func(x int) int {
+		n := active.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		active.Add(-1)
+		return x
+	}), func(int) {})
+	if p := peak.Load(); p > 3 {
+		t.Fatalf("peak concurrency %d, want <= 3", p)
+	}
+	if p := peak.Load(); p < 2 {
+		t.Fatalf("peak concurrency %d, want calls to overlap", p)
+	}
+}
+
+func TestParallelMapEarlyStopDrains(t *testing.T) {
+	var active atomic.Int32
+	pulled := 0
+	src := Inspect(Range1(1000), func(int) { pulled++ })
+	got := ToSlice(Take(ParallelMap(src, 4, func(x int) int {
+		active.Add(1)
+		defer active.Add(-1)
+		time.Sleep(time.Millisecond)
+		return x
+	}), 3))
+	if !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Fatalf("got %v, want [0 1 2]", got)
+	}
+	if n := active.Load(); n != 0 {
+		t.Fatalf("%d calls still running after early stop", n)
+	}
+	if pulled > 3+4 {
+		t.Fatalf("pulled %d elements, want at most 7", pulled)
+	}
+	stopEarly(ParallelMap(Range1(10), 2, func(x int) int { return x }))
+}
+
+func TestParallelMapPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Fatalf("recovered %v, want boom", r)
+		}
+	}()
+	ForEach(ParallelMap(Range1(10), 2, func(x int) int {
+		if x == 5 {
+			panic("boom")
+		}
+		return x
+	}), func(int) {})
+	t.Fatal("expected panic")
+}
+
+func TestParallelMapUnordered(t *testing.T) {
+	got := ToSlice(ParallelMapUnordered(Range1(20), 4, func(x int) int {
+		time.Sleep(time.Duration(20-x) * 50 * time.Microsecond)
+		return x * 2
+	}))
+	slices.Sort(got)
+	want := ToSlice(Map(Range1(20), func(x int) int { return x * 2 }))
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	var active atomic.Int32
+	n := Size(Take(ParallelMapUnordered(Repeat(1), 4, func(x int) int {
+		active.Add(1)
+		defer active.Add(-1)
+		return x
+	}), 5))
+	if n != 5 {
+		t.Fatalf("got %d elements, want 5", n)
+	}
+	if a := active.Load(); a != 0 {
+		t.Fatalf("%d calls still running after early stop", a)
+	}
+}
+
+func TestParallelMap2(t *testing.T) {
+	var keys, values []int
+	for k, v := range ParallelMap2(Enumerate(Range2(10, 20)), 3, func(k, v int) (int, int) {
+		return k * 10, v + 1
+	}) {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	if !reflect.DeepEqual(keys, []int{0, 10, 20, 30, 40, 50, 60, 70, 80, 90}) {
+		t.Fatalf("keys got %v", keys)
+	}
+	if !reflect.DeepEqual(values, []int{11, 12, 13, 14, 15, 16, 17, 18, 19, 20}) {
+		t.Fatalf("values got %v", values)
+	}
+	stopEarly2(ParallelMap2(Enumerate(Range1(10)), 2, func(k, v int) (int, int) { return k, v }))
+}
+
+func TestTryParallelMap(t *testing.T) {
+	wantErr := errors.New("bad")
+	var values []int
+	var errs []error
+	for v, err := range TryParallelMap(Range1(10), 3, func(x int) (int, error) {
+		if x == 4 || x == 7 {
+			return -x, wantErr
+		}
+		return x, nil
+	}) {
+		values = append(values, v)
+		errs = append(errs, err)
+	}
+	if !reflect.DeepEqual(values, []int{0, 1, 2, 3, -4}) {
+		t.Fatalf("values got %v", values)
+	}
+	for i, err := range errs {
+		if i < 4 && err != nil {
+			t.Fatalf("errs[%d] = %v, want nil", i, err)
+		}
+	}
+	if !errors.Is(errs[4], wantErr) {
+		t.Fatalf("last error %v, want %v", errs[4], wantErr)
+	}
+
+	got := 0
+	for _, err := range TryParallelMap(Range1(5), 2, func(x int) (int, error) { return x, nil }) {
+		if err != nil {
+			t.Fatalf("got error %v", err)
+		}
+		got++
+	}
+	if got != 5 {
+		t.Fatalf("got %d results, want 5", got)
+	}
+	stopEarly2(TryParallelMap(Range1(10), 2, func(x int) (int, error) { return x, nil }))
+}
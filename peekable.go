@@ -0,0 +1,198 @@
+package xiter
+
+import "iter"
+
+// ============================================================================
+// Peekable
+// ============================================================================
+
+// Peekable is a pull-style iterator over a sequence with one element of
+// lookahead and the ability to push elements back, as needed by hand-written
+// parsers and tokenizers. It wraps iter.Pull: the source is started lazily on
+// the first call that needs an element and released as soon as it is
+// exhausted or Stop is called. A Peekable that is abandoned before either
+// happens keeps the source suspended, so pair its creation with a deferred
+// Stop:
+//
+//	p := NewPeekable(tokens)
+//	defer p.Stop()
+//	for tok, ok := p.Next(); ok; tok, ok = p.Next() {
+//	    if next, ok := p.Peek(); ok && next == "(" { ... }
+//	}
+//
+// A Peekable is not safe for concurrent use.
+type Peekable[E any] struct {
+	src  iter.Seq[E]
+	next func() (E, bool)
+	stop func()
+
+	// pending holds peeked and pushed-back elements; the last one is
+	// returned next.
+	pending []E
+	done    bool
+}
+
+// NewPeekable returns a Peekable over s. No element is pulled from s until
+// Next, Peek, NextIf or All needs one.
+func NewPeekable[E any](s iter.Seq[E]) *Peekable[E] {
+	return &Peekable[E]{src: s}
+}
+
+// Next returns the next element and true, or the zero value and false once
+// the sequence is exhausted or stopped. Pushed-back elements are returned
+// first, most recently pushed first.
+func (p *Peekable[E]) Next() (E, bool) {
+	if n := len(p.pending); n > 0 {
+		e := p.pending[n-1]
+		var zero E
+		p.pending[n-1] = zero
+		p.pending = p.pending[:n-1]
+		return e, true
+	}
+	return p.pull()
+}
+
+// Peek returns the element the next call to Next would return without
+// consuming it. It returns the zero value and false if there is none.
+func (p *Peekable[E]) Peek() (E, bool) {
+	if n := len(p.pending); n > 0 {
+		return p.pending[n-1], true
+	}
+	e, ok := p.pull()
+	if ok {
+		p.pending = append(p.pending, e)
+	}
+	return e, ok
+}
+
+// PushBack returns e to the front of the sequence so that the next call to
+// Next or Peek returns it. Any number of elements may be pushed back; they
+// come out in reverse order of pushing. Pushing back works even after the
+// source is exhausted or stopped.
+func (p *Peekable[E]) PushBack(e E) {
+	p.pending = append(p.pending, e)
+}
+
+// NextIf consumes and returns the next element only if pred reports true for
+// it. Otherwise the element stays in place and NextIf returns the zero value
+// and false.
+//
+//	digits := ""
+//	for d, ok := p.NextIf(isDigit); ok; d, ok = p.NextIf(isDigit) {
+//	    digits += string(d)
+//	}
+func (p *Peekable[E]) NextIf(pred func(E) bool) (E, bool) {
+	e, ok := p.Peek()
+	if !ok || !pred(e) {
+		var zero E
+		return zero, false
+	}
+	return p.Next()
+}
+
+// Stop releases the source and discards any peeked or pushed-back elements.
+// After Stop, Next and Peek report no more elements unless new ones are
+// pushed back. Stop may be called any number of times.
+func (p *Peekable[E]) Stop() {
+	clear(p.pending)
+	p.pending = p.pending[:0]
+	p.release()
+}
+
+// All returns a push sequence over the remaining elements, starting with any
+// peeked or pushed-back ones. Every element it yields is consumed from p, so
+// when the consumer breaks early, p resumes right after the last yielded
+// element.
+//
+//	p.NextIf(isHeader)
+//	for line := range p.All() { ... }  // the rest of the input
+func (p *Peekable[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for {
+			e, ok := p.Next()
+			if !ok || !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// pull returns the next element of the source, starting it on first use and
+// releasing it once exhausted.
+func (p *Peekable[E]) pull() (E, bool) {
+	if p.done {
+		var zero E
+		return zero, false
+	}
+	if p.next == nil {
+		p.next, p.stop = iter.Pull(p.src)
+	}
+	e, ok := p.next()
+	if !ok {
+		p.release()
+	}
+	return e, ok
+}
+
+func (p *Peekable[E]) release() {
+	if p.done {
+		return
+	}
+	p.done = true
+	if p.stop != nil {
+		p.stop()
+	}
+}
+
+// Peekable2 is the key/value variant of Peekable. It offers the same
+// lookahead and push-back operations over an iter.Seq2 and has the same
+// release rules: the source is released when exhausted or on Stop.
+type Peekable2[K, V any] struct {
+	p *Peekable[pair[K, V]]
+}
+
+// NewPeekable2 returns a Peekable2 over s. No pair is pulled from s until
+// Next, Peek, NextIf or All needs one.
+func NewPeekable2[K, V any](s iter.Seq2[K, V]) *Peekable2[K, V] {
+	return &Peekable2[K, V]{p: NewPeekable(zipPairs(s))}
+}
+
+// Next returns the next key/value pair and true, or zero values and false
+// once the sequence is exhausted or stopped.
+func (p *Peekable2[K, V]) Next() (K, V, bool) {
+	kv, ok := p.p.Next()
+	return kv.k, kv.v, ok
+}
+
+// Peek returns the pair the next call to Next would return without
+// consuming it.
+func (p *Peekable2[K, V]) Peek() (K, V, bool) {
+	kv, ok := p.p.Peek()
+	return kv.k, kv.v, ok
+}
+
+// PushBack returns the pair (k, v) to the front of the sequence.
+func (p *Peekable2[K, V]) PushBack(k K, v V) {
+	p.p.PushBack(pair[K, V]{k, v})
+}
+
+// NextIf consumes and returns the next pair only if pred reports true for it.
+func (p *Peekable2[K, V]) NextIf(pred func(K, V) bool) (K, V, bool) {
+	kv, ok := p.p.NextIf(func(kv pair[K, V]) bool { return pred(kv.k, kv.v) })
+	return kv.k, kv.v, ok
+}
+
+// Stop releases the source and discards any peeked or pushed-back pairs.
+func (p *Peekable2[K, V]) Stop() { p.p.Stop() }
+
+// All returns a push sequence over the remaining pairs, starting with any
+// peeked or pushed-back ones.
+func (p *Peekable2[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for kv := range p.p.All() {
+			if !yield(kv.k, kv.v) {
+				return
+			}
+		}
+	}
+}
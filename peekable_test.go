@@ -0,0 +1,141 @@
+package xiter
+
+import (
+	"reflect"
+	"testing"
+)
+
+// trackedSource returns a source over vs together with flags reporting
+// whether it was started and whether it has been released.
+func trackedSource(vs ...int) (s func(func(int) bool), started, released *bool) {
+	started, released = new(bool), new(bool)
+	return func(yield func(int) bool) {
+		*started = true
+		defer func() { *released = true }()
+		for _, v := range vs {
+			if !yield(v) {
+				return
+			}
+		}
+	}, started, released
+}
+
+func TestPeekable(t *testing.T) {
+	src, started, released := trackedSource(1, 2, 3)
+	p := NewPeekable(src)
+	if *started {
+		t.Fatal("source started before the first pull")
+	}
+	if v, ok := p.Peek(); v != 1 || !ok {
+		t.Fatalf("Peek got (%v, %v), want (1, true)", v, ok)
+	}
+	if v, ok := p.Peek(); v != 1 || !ok {
+		t.Fatalf("second Peek got (%v, %v), want (1, true)", v, ok)
+	}
+	if v, ok := p.Next(); v != 1 || !ok {
+		t.Fatalf("Next got (%v, %v), want (1, true)", v, ok)
+	}
+	p.PushBack(10)
+	p.PushBack(20)
+	var got []int
+	for v, ok := p.Next(); ok; v, ok = p.Next() {
+		got = append(got, v)
+	}
+	if want := []int{20, 10, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if !*released {
+		t.Fatal("source not released after exhaustion")
+	}
+	if _, ok := p.Peek(); ok {
+		t.Fatal("Peek after exhaustion reported an element")
+	}
+	p.PushBack(7)
+	if v, ok := p.Next(); v != 7 || !ok {
+		t.Fatalf("Next after PushBack got (%v, %v), want (7, true)", v, ok)
+	}
+	p.Stop()
+	p.Stop()
+}
+
+func TestPeekableNextIf(t *testing.T) {
+	p := NewPeekable(seqOf(2, 4, 5, 6))
+	defer p.Stop()
+	even := func(v int) bool { return v%2 == 0 }
+	var got []int
+	for v, ok := p.NextIf(even); ok; v, ok = p.NextIf(even) {
+		got = append(got, v)
+	}
+	if !reflect.DeepEqual(got, []int{2, 4}) {
+		t.Fatalf("got %v, want [2 4]", got)
+	}
+	if v, ok := p.Next(); v != 5 || !ok {
+		t.Fatalf("Next got (%v, %v), want (5, true)", v, ok)
+	}
+}
+
+func TestPeekableStop(t *testing.T) {
+	src, _, released := trackedSource(1, 2, 3)
+	p := NewPeekable(src)
+	p.Peek()
+	p.Stop()
+	if !*released {
+		t.Fatal("Stop did not release the source")
+	}
+	if _, ok := p.Next(); ok {
+		t.Fatal("Next after Stop reported an element")
+	}
+
+	// Stopping a Peekable that never pulled must not start the source.
+	src, started, _ := trackedSource(1)
+	NewPeekable(src).Stop()
+	if *started {
+		t.Fatal("Stop started the source")
+	}
+}
+
+func TestPeekableAll(t *testing.T) {
+	p := NewPeekable(seqOf(1, 2, 3, 4, 5))
+	defer p.Stop()
+	p.Next()
+	p.PushBack(0)
+	var got []int
+	for v := range p.All() {
+		got = append(got, v)
+		if v == 3 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(got, []int{0, 2, 3}) {
+		t.Fatalf("got %v, want [0 2 3]", got)
+	}
+	if got := ToSlice(p.All()); !reflect.DeepEqual(got, []int{4, 5}) {
+		t.Fatalf("resumed got %v, want [4 5]", got)
+	}
+}
+
+func TestPeekable2(t *testing.T) {
+	type kv = struct {
+		K string
+		V int
+	}
+	p := NewPeekable2(seq2Of(kv{"a", 1}, kv{"b", 2}, kv{"c", 3}))
+	defer p.Stop()
+	if k, v, ok := p.Peek(); k != "a" || v != 1 || !ok {
+		t.Fatalf("Peek got (%v, %v, %v)", k, v, ok)
+	}
+	if k, v, ok := p.NextIf(func(k string, _ int) bool { return k == "b" }); ok {
+		t.Fatalf("NextIf consumed (%v, %v)", k, v)
+	}
+	if k, v, ok := p.Next(); k != "a" || v != 1 || !ok {
+		t.Fatalf("Next got (%v, %v, %v)", k, v, ok)
+	}
+	p.PushBack("z", 26)
+	got := ToMap(p.All())
+	if want := map[string]int{"z": 26, "b": 2, "c": 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if _, _, ok := p.Next(); ok {
+		t.Fatal("Next after exhaustion reported a pair")
+	}
+}
@@ -0,0 +1,30 @@
+// Package pipeline runs concurrent, multi-stage batch jobs over the sequences
+// of package xiter, replacing hand-rolled combinations of goroutines,
+// channels and error groups.
+//
+// A Pipeline is a lazy description of a stream of values. It starts from a
+// sequence (From, FromErr) and is extended by stages (Map, Filter, FlatMap),
+// each of which runs on its own pool of worker goroutines and writes to its
+// own buffered channel:
+//
+//	users := pipeline.From(ids)
+//	loaded := pipeline.Map(users, fetch, pipeline.Workers(16), pipeline.Buffer(64))
+//	active := pipeline.Filter(loaded, isActive)
+//	err := pipeline.TryForEach(ctx, active, store)
+//
+// Nothing runs until a terminal — Run, TryForEach or All — is called, and
+// every call runs the whole pipeline afresh. Channels between stages are
+// bounded, so a slow stage applies backpressure all the way back to the
+// source, which is only pulled as fast as the pipeline drains.
+//
+// The first error returned by any stage, or by the terminal's callback,
+// cancels the context passed to every stage function and is returned by the
+// terminal; errors that follow it are dropped. A panic in a stage function
+// cancels the pipeline the same way and is re-raised on the terminal's
+// goroutine. Whichever way a run ends — exhaustion, error, panic,
+// cancellation of the caller's context, or an early break out of All — every
+// goroutine it started has exited before the terminal returns.
+//
+// With more than one worker, a stage emits results in completion order
+// rather than input order.
+package pipeline
@@ -0,0 +1,48 @@
+package pipeline_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-board/xiter"
+	"github.com/go-board/xiter/pipeline"
+)
+
+func Example() {
+	words := slices.Values([]string{"go", "iter", "pipe", "x", "stage"})
+	upper := pipeline.Map(pipeline.From(words), func(_ context.Context, w string) (string, error) {
+		return strings.ToUpper(w), nil
+	}, pipeline.Workers(4), pipeline.Buffer(8))
+	long := pipeline.Filter(upper, func(_ context.Context, w string) (bool, error) {
+		return len(w) > 2, nil
+	})
+
+	var got []string
+	err := pipeline.TryForEach(context.Background(), long, func(w string) error {
+		got = append(got, w)
+		return nil
+	})
+	slices.Sort(got) // four workers emit in completion order
+	fmt.Println(got, err)
+	// Output:
+	// [ITER PIPE STAGE] <nil>
+}
+
+func ExampleAll() {
+	squares := pipeline.Map(pipeline.From(xiter.Range1(5)), func(_ context.Context, v int) (int, error) {
+		if v == 3 {
+			return 0, fmt.Errorf("cannot square %d", v)
+		}
+		return v * v, nil
+	})
+	for v, err := range pipeline.All(context.Background(), squares) {
+		fmt.Println(v, err)
+	}
+	// Output:
+	// 0 <nil>
+	// 1 <nil>
+	// 4 <nil>
+	// 0 cannot square 3
+}
@@ -0,0 +1,295 @@
+package pipeline
+
+import (
+	"context"
+	"iter"
+	"runtime"
+	"sync"
+)
+
+// Pipeline is a lazy description of a stream of values of type E, built from
+// a source and a chain of stages. It is a value that can be run any number of
+// times; see the package documentation for how a run behaves.
+type Pipeline[E any] struct {
+	start func(r *runner) <-chan E
+}
+
+// Option configures a stage.
+type Option func(*config)
+
+type config struct {
+	workers int
+	buffer  int
+}
+
+// Workers sets the number of goroutines that run the stage's function
+// concurrently. When n <= 0, runtime.GOMAXPROCS(0) workers are used. The
+// default is 1, which preserves input order.
+func Workers(n int) Option {
+	return func(c *config) {
+		if n <= 0 {
+			n = runtime.GOMAXPROCS(0)
+		}
+		c.workers = n
+	}
+}
+
+// Buffer sets how many results the stage may hold before its workers block
+// waiting for the next stage. When n <= 0, or by default, results are handed
+// over one at a time.
+func Buffer(n int) Option {
+	return func(c *config) { c.buffer = max(n, 0) }
+}
+
+// From returns a Pipeline over the elements of s, which is pulled on a
+// goroutine of its own as the first stage consumes them.
+func From[E any](s iter.Seq[E]) Pipeline[E] {
+	return Pipeline[E]{start: func(r *runner) <-chan E {
+		out := make(chan E)
+		r.spawn(func() error {
+			defer close(out)
+			for e := range s {
+				if !send(r.ctx, out, e) {
+					return nil
+				}
+			}
+			return nil
+		})
+		return out
+	}}
+}
+
+// FromErr is like From for an error-carrying source such as those of package
+// xio: the first pair with a non-nil error fails the pipeline with that error.
+func FromErr[E any](s iter.Seq2[E, error]) Pipeline[E] {
+	return Pipeline[E]{start: func(r *runner) <-chan E {
+		out := make(chan E)
+		r.spawn(func() error {
+			defer close(out)
+			for e, err := range s {
+				if err != nil {
+					return err
+				}
+				if !send(r.ctx, out, e) {
+					return nil
+				}
+			}
+			return nil
+		})
+		return out
+	}}
+}
+
+// Map returns a Pipeline that applies f to every element of p. An error from
+// f fails the pipeline.
+//
+//	Map(From(urls), fetch, Workers(8))
+func Map[In, Out any](p Pipeline[In], f func(context.Context, In) (Out, error), opts ...Option) Pipeline[Out] {
+	return stage(p, opts, func(ctx context.Context, e In, emit func(Out) bool) error {
+		out, err := f(ctx, e)
+		if err != nil {
+			return err
+		}
+		emit(out)
+		return nil
+	})
+}
+
+// Filter returns a Pipeline over the elements of p for which f reports true.
+// An error from f fails the pipeline.
+func Filter[E any](p Pipeline[E], f func(context.Context, E) (bool, error), opts ...Option) Pipeline[E] {
+	return stage(p, opts, func(ctx context.Context, e E, emit func(E) bool) error {
+		keep, err := f(ctx, e)
+		if err != nil {
+			return err
+		}
+		if keep {
+			emit(e)
+		}
+		return nil
+	})
+}
+
+// FlatMap returns a Pipeline over the elements of every sequence f returns
+// for the elements of p. Each sequence is iterated on the worker that called
+// f, so its elements come out in order, though with more than one worker they
+// may be interleaved with those of other inputs. An error from f fails the
+// pipeline.
+func FlatMap[In, Out any](p Pipeline[In], f func(context.Context, In) (iter.Seq[Out], error), opts ...Option) Pipeline[Out] {
+	return stage(p, opts, func(ctx context.Context, e In, emit func(Out) bool) error {
+		s, err := f(ctx, e)
+		if err != nil {
+			return err
+		}
+		for out := range s {
+			if !emit(out) {
+				break
+			}
+		}
+		return nil
+	})
+}
+
+// Run runs p, discarding its output, and returns the first error, or the
+// caller's ctx.Err() if ctx is done before p is exhausted.
+func Run[E any](ctx context.Context, p Pipeline[E]) error {
+	return drive(ctx, p, func(E) error { return nil })
+}
+
+// TryForEach runs p and calls f for every element it produces, on the
+// caller's goroutine. An error from f stops the pipeline like a stage error.
+// It returns the first error, or the caller's ctx.Err() if ctx is done before
+// p is exhausted.
+func TryForEach[E any](ctx context.Context, p Pipeline[E], f func(E) error) error {
+	return drive(ctx, p, f)
+}
+
+// All returns a sequence that runs p on every iteration and yields its
+// elements as (e, nil) pairs. If the run fails, the error is yielded as a
+// final (zero, err) pair. Breaking out of the loop stops the pipeline, and
+// every goroutine has exited before the loop ends.
+//
+//	for v, err := range All(ctx, p) {
+//	    if err != nil { ... }
+//	}
+func All[E any](ctx context.Context, p Pipeline[E]) iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		errStop := &stopped{}
+		err := drive(ctx, p, func(e E) error {
+			if !yield(e, nil) {
+				return errStop
+			}
+			return nil
+		})
+		if err != nil && err != error(errStop) {
+			var zero E
+			yield(zero, err)
+		}
+	}
+}
+
+// stopped marks the end of a run requested by the consumer of All.
+type stopped struct{}
+
+func (*stopped) Error() string { return "pipeline: stopped by consumer" }
+
+// stage builds a stage that runs work for every input on its configured
+// number of workers. work reports results through emit, which returns false
+// once the pipeline is shutting down.
+func stage[In, Out any](p Pipeline[In], opts []Option, work func(ctx context.Context, e In, emit func(Out) bool) error) Pipeline[Out] {
+	cfg := config{workers: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return Pipeline[Out]{start: func(r *runner) <-chan Out {
+		in := p.start(r)
+		out := make(chan Out, cfg.buffer)
+		emit := func(e Out) bool { return send(r.ctx, out, e) }
+		var wg sync.WaitGroup
+		wg.Add(cfg.workers)
+		for range cfg.workers {
+			r.spawn(func() error {
+				defer wg.Done()
+				for e := range in {
+					if r.ctx.Err() != nil {
+						return nil
+					}
+					if err := work(r.ctx, e, emit); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
+		r.spawn(func() error {
+			wg.Wait()
+			close(out)
+			return nil
+		})
+		return out
+	}}
+}
+
+// send delivers e on ch unless ctx is done first.
+func send[E any](ctx context.Context, ch chan<- E, e E) bool {
+	select {
+	case ch <- e:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// drive runs p and calls f for each of its elements until p is exhausted or
+// the run fails. It returns only after every goroutine of the run has exited.
+func drive[E any](ctx context.Context, p Pipeline[E], f func(E) error) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	r := &runner{ctx: runCtx, cancel: cancel}
+	out := p.start(r)
+	func() {
+		// Shut down even if f panics.
+		defer func() {
+			r.cancel()
+			r.wg.Wait()
+		}()
+		for e := range out {
+			if err := f(e); err != nil {
+				r.fail(err)
+				return
+			}
+		}
+	}()
+	if r.panicked {
+		panic(r.p)
+	}
+	if r.err != nil {
+		return r.err
+	}
+	return ctx.Err()
+}
+
+// runner tracks the goroutines and the outcome of one run of a pipeline.
+type runner struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	err      error
+	panicked bool
+	p        any
+}
+
+// spawn runs f on a new goroutine of the run. An error returned by f or a
+// panic in f fails the run.
+func (r *runner) spawn(f func() error) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer func() {
+			if p := recover(); p != nil {
+				r.mu.Lock()
+				if !r.panicked {
+					r.panicked, r.p = true, p
+				}
+				r.mu.Unlock()
+				r.cancel()
+			}
+		}()
+		if err := f(); err != nil {
+			r.fail(err)
+		}
+	}()
+}
+
+// fail records err unless the run has already failed, and cancels the run.
+// Errors reported after the run was cancelled for another reason, typically
+// ctx.Err() returned by a stage function, are not recorded.
+func (r *runner) fail(err error) {
+	r.mu.Lock()
+	if r.err == nil && r.ctx.Err() == nil {
+		r.err = err
+	}
+	r.mu.Unlock()
+	r.cancel()
+}
@@ -0,0 +1,219 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"reflect"
+	"runtime"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func double(_ context.Context, v int) (int, error) { return v * 2, nil }
+
+func isEven(_ context.Context, v int) (bool, error) { return v%2 == 0, nil }
+
+func upTo(n int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := range n {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func collect[E any](t *testing.T, p Pipeline[E]) []E {
+	t.Helper()
+	var got []E
+	if err := TryForEach(context.Background(), p, func(e E) error {
+		got = append(got, e)
+		return nil
+	}); err != nil {
+		t.Fatalf("TryForEach got error %v", err)
+	}
+	return got
+}
+
+// checkNoLeak fails the test if goroutines started by f are still running
+// shortly after it returns.
+func checkNoLeak(t *testing.T, f func()) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	f()
+	for range 100 {
+		if runtime.NumGoroutine() <= before {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("%d goroutines running, want at most %d", runtime.NumGoroutine(), before)
+}
+
+func TestStages(t *testing.T) {
+	p := Filter(Map(From(upTo(10)), double), func(_ context.Context, v int) (bool, error) { return v%4 == 0, nil })
+	if got := collect(t, p); !reflect.DeepEqual(got, []int{0, 4, 8, 12, 16}) {
+		t.Fatalf("got %v, want [0 4 8 12 16]", got)
+	}
+	// A pipeline can be run again.
+	if got := collect(t, p); len(got) != 5 {
+		t.Fatalf("second run got %v", got)
+	}
+
+	fm := FlatMap(From(upTo(3)), func(_ context.Context, v int) (iter.Seq[int], error) {
+		return slices.Values(slices.Repeat([]int{v}, v)), nil
+	})
+	if got := collect(t, fm); !reflect.DeepEqual(got, []int{1, 2, 2}) {
+		t.Fatalf("FlatMap got %v, want [1 2 2]", got)
+	}
+}
+
+func TestWorkers(t *testing.T) {
+	var running, peak atomic.Int32
+	slow := func(_ context.Context, v int) (int, error) {
+		n := running.Add(1)
+		for {
+			old := peak.Load()
+			if n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		return v, nil
+	}
+	got := collect(t, Map(From(upTo(100)), slow, Workers(4), Buffer(8)))
+	slices.Sort(got)
+	if !reflect.DeepEqual(got, slices.Collect(upTo(100))) {
+		t.Fatalf("got %v", got)
+	}
+	if n := peak.Load(); n > 4 || n < 2 {
+		t.Fatalf("peak concurrency %d, want 2..4", n)
+	}
+}
+
+func TestBackpressure(t *testing.T) {
+	var pulled atomic.Int32
+	src := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			pulled.Add(1)
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	p := Map(From(src), double, Buffer(4))
+	checkNoLeak(t, func() {
+		for _, err := range All(context.Background(), p) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			time.Sleep(5 * time.Millisecond)
+			break
+		}
+	})
+	// One element at the consumer, four buffered, one in the worker, one
+	// being sent by the source and one more it may have pulled.
+	if n := pulled.Load(); n > 8 {
+		t.Fatalf("source pulled %d elements ahead of a stalled consumer", n)
+	}
+}
+
+func TestStageError(t *testing.T) {
+	errBoom := errors.New("boom")
+	fail := func(ctx context.Context, v int) (int, error) {
+		if v == 5 {
+			return 0, errBoom
+		}
+		if v > 5 {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		return v, nil
+	}
+	checkNoLeak(t, func() {
+		err := Run(context.Background(), Map(From(upTo(1000)), fail, Workers(4)))
+		if !errors.Is(err, errBoom) {
+			t.Fatalf("got %v, want %v", err, errBoom)
+		}
+	})
+
+	// Elements in flight when a stage fails are dropped; the error comes last.
+	var last error
+	for _, err := range All(context.Background(), Map(FromErr(func(yield func(int, error) bool) {
+		_ = yield(1, nil) && yield(0, errBoom) && yield(2, nil)
+	}), double)) {
+		last = err
+	}
+	if last != errBoom {
+		t.Fatalf("last error got %v, want %v", last, errBoom)
+	}
+}
+
+func TestTryForEachError(t *testing.T) {
+	errStop := errors.New("stop")
+	checkNoLeak(t, func() {
+		err := TryForEach(context.Background(), Map(From(upTo(1000)), double, Workers(3)), func(v int) error {
+			if v == 10 {
+				return errStop
+			}
+			return nil
+		})
+		if err != errStop {
+			t.Fatalf("got %v, want %v", err, errStop)
+		}
+	})
+}
+
+func TestContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	infinite := func(yield func(int) bool) {
+		for i := 0; yield(i); i++ {
+		}
+	}
+	checkNoLeak(t, func() {
+		n := 0
+		err := TryForEach(ctx, Filter(From(infinite), isEven, Workers(2)), func(int) error {
+			if n++; n == 3 {
+				cancel()
+			}
+			return nil
+		})
+		if err != context.Canceled {
+			t.Fatalf("got %v, want %v", err, context.Canceled)
+		}
+	})
+}
+
+func TestAllBreak(t *testing.T) {
+	checkNoLeak(t, func() {
+		for v, err := range All(context.Background(), Map(From(upTo(1000)), double, Workers(4), Buffer(16))) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v > 10 {
+				break
+			}
+		}
+	})
+}
+
+func TestPanic(t *testing.T) {
+	checkNoLeak(t, func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Fatalf("recovered %v, want boom", r)
+			}
+		}()
+		Run(context.Background(), Map(From(upTo(100)), func(_ context.Context, v int) (int, error) {
+			if v == 3 {
+				panic("boom")
+			}
+			return v, nil
+		}, Workers(2)))
+		t.Fatal("Run returned without panicking")
+	})
+}
@@ -0,0 +1,368 @@
+package xiter
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+)
+
+// ============================================================================
+// Quantiles
+// ============================================================================
+
+// Median returns the exact median of the elements of s, averaging the two
+// middle elements when the count is even. It returns (0, false) when s is
+// empty. Like Quantiles, it materializes s.
+//
+//	Median(seqOf(3, 1, 4, 1, 5))  // returns (3, true)
+//	Median(seqOf(1, 2, 3, 4))     // returns (2.5, true)
+func Median[N Number](s iter.Seq[N]) (float64, bool) {
+	qs, ok := Quantiles(s, 0.5)
+	if !ok {
+		return 0, false
+	}
+	return qs[0], true
+}
+
+// Quantiles returns the exact q-quantile of the elements of s for every q in
+// qs, in the order given. It returns (nil, false) when s is empty.
+//
+// The elements are collected into a slice and each quantile is found by
+// selection rather than by sorting, which takes linear time per quantile on
+// average. Between the two elements nearest to rank q*(n-1) the result is
+// linearly interpolated, the definition used by most spreadsheets and by
+// NumPy's default. A q outside [0, 1] yields NaN. NaN elements order before
+// all other values, as in cmp.Compare.
+//
+// Quantiles needs memory for the whole sequence; for long streams use a
+// Sketch, which answers approximately in bounded memory.
+//
+//	Quantiles(Range2(1, 101), 0.5, 0.9, 0.99)  // returns ([50.5 90.1 99.01], true)
+func Quantiles[N Number](s iter.Seq[N], qs ...float64) ([]float64, bool) {
+	var xs []float64
+	for x := range s {
+		xs = append(xs, float64(x))
+	}
+	if len(xs) == 0 {
+		return nil, false
+	}
+	out := make([]float64, len(qs))
+	order := make([]int, len(qs))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int { return cmp.Compare(qs[a], qs[b]) })
+
+	// Selecting in ascending order of q lets every selection start where the
+	// previous one ended: everything left of it is no greater.
+	lo := 0
+	for _, i := range order {
+		q := qs[i]
+		if !(q >= 0 && q <= 1) {
+			out[i] = math.NaN()
+			continue
+		}
+		h := q * float64(len(xs)-1)
+		j := int(h)
+		selectNth(xs[lo:], j-lo)
+		lo = j
+		v := xs[j]
+		if frac := h - float64(j); frac > 0 {
+			next := slices.MinFunc(xs[j+1:], cmp.Compare[float64])
+			v += frac * (next - v)
+		}
+		out[i] = v
+	}
+	return out, true
+}
+
+// selectNth reorders xs so that xs[k] holds the element that would be there
+// if xs were sorted, with no greater element before it and no smaller one
+// after it.
+func selectNth(xs []float64, k int) {
+	// Quickselect with a median-of-three pivot, falling back to sorting when
+	// the partitions stay unbalanced for too long.
+	budget := 2 * bits.Len(uint(len(xs)))
+	for len(xs) > 16 {
+		if budget == 0 {
+			slices.SortFunc(xs, cmp.Compare[float64])
+			return
+		}
+		budget--
+		m := len(xs) / 2
+		sort3(xs, 0, m, len(xs)-1)
+		pivot := xs[m]
+		// Three-way partition: xs[:lt] < pivot, xs[lt:gt] == pivot, xs[gt:] > pivot.
+		lt, i, gt := 0, 0, len(xs)
+		for i < gt {
+			switch c := cmp.Compare(xs[i], pivot); {
+			case c < 0:
+				xs[lt], xs[i] = xs[i], xs[lt]
+				lt++
+				i++
+			case c > 0:
+				gt--
+				xs[i], xs[gt] = xs[gt], xs[i]
+			default:
+				i++
+			}
+		}
+		switch {
+		case k < lt:
+			xs = xs[:lt]
+		case k >= gt:
+			xs, k = xs[gt:], k-gt
+		default:
+			return
+		}
+	}
+	slices.SortFunc(xs, cmp.Compare[float64])
+}
+
+// sort3 orders xs[a], xs[b] and xs[c] ascending.
+func sort3(xs []float64, a, b, c int) {
+	if cmp.Less(xs[b], xs[a]) {
+		xs[a], xs[b] = xs[b], xs[a]
+	}
+	if cmp.Less(xs[c], xs[b]) {
+		xs[b], xs[c] = xs[c], xs[b]
+		if cmp.Less(xs[b], xs[a]) {
+			xs[a], xs[b] = xs[b], xs[a]
+		}
+	}
+}
+
+// DefaultSketchK is the accuracy parameter used by a Sketch created with
+// k <= 0, and by the zero Sketch.
+const DefaultSketchK = 200
+
+// Sketch estimates quantiles of a stream of float64 values in bounded
+// memory. It implements the KLL sketch of Karnin, Lang and Liberty: values are
+// kept in a hierarchy of buffers, and a full buffer is sorted and halved by
+// keeping every other value at random, each survivor standing in for twice as
+// many inputs one level up.
+//
+// Accuracy is governed by the parameter k. A Sketch retains at most about
+// 3k values regardless of how many it has seen, and the estimated q-quantile
+// has a true rank that differs from q·n by less than about 1.7/k·n with 99%
+// probability, i.e. within ±0.85 percentiles for the default k = 200; with
+// k = 1000 it is within ±0.17 percentiles. The error is additive in rank and
+// independent of the distribution, so extreme quantiles such as p99.9 are
+// located just as precisely as the median relative to n, but no more. The
+// minimum and maximum are tracked exactly.
+//
+// Sketches of separate shards of a stream can be combined with Merge into a
+// sketch of the whole stream with the same accuracy guarantee. The zero
+// value is an empty sketch using DefaultSketchK. A Sketch is not safe for
+// concurrent use; give each goroutine its own and merge them afterwards.
+type Sketch struct {
+	k       int
+	levels  [][]float64 // levels[h] holds values of weight 2^h
+	size    int         // number of values held in all levels
+	maxSize int         // total capacity of the levels
+	n       int
+	min     float64
+	max     float64
+	rng     *rand.Rand
+}
+
+// NewSketch returns an empty Sketch with accuracy parameter k, or
+// DefaultSketchK when k <= 0. Values of k below 8 are raised to 8.
+func NewSketch(k int) *Sketch {
+	if k <= 0 {
+		k = DefaultSketchK
+	}
+	return &Sketch{k: max(k, 8)}
+}
+
+// ToSketch feeds every element of s into a new Sketch with accuracy parameter
+// k (see NewSketch) and returns it. NaN elements are ignored.
+//
+//	sk := ToSketch(latencies, 0)
+//	p50, p99 := sk.Quantile(0.5), sk.Quantile(0.99)
+func ToSketch[N Number](s iter.Seq[N], k int) *Sketch {
+	sk := NewSketch(k)
+	for x := range s {
+		sk.Add(float64(x))
+	}
+	return sk
+}
+
+// Add records x. NaN values are ignored.
+func (s *Sketch) Add(x float64) {
+	if math.IsNaN(x) {
+		return
+	}
+	s.init()
+	if s.n == 0 || x < s.min {
+		s.min = x
+	}
+	if s.n == 0 || x > s.max {
+		s.max = x
+	}
+	s.n++
+	s.levels[0] = append(s.levels[0], x)
+	s.size++
+	for s.size >= s.maxSize {
+		s.compress()
+	}
+}
+
+// Merge adds everything recorded in o to s, as if every value added to o had
+// been added to s. o is left unchanged. Merging sketches with different k is
+// allowed; the result keeps the k of s.
+func (s *Sketch) Merge(o *Sketch) {
+	if o == nil || o.n == 0 {
+		return
+	}
+	s.init()
+	if s.n == 0 || o.min < s.min {
+		s.min = o.min
+	}
+	if s.n == 0 || o.max > s.max {
+		s.max = o.max
+	}
+	s.n += o.n
+	for len(s.levels) < len(o.levels) {
+		s.grow()
+	}
+	for h, lvl := range o.levels {
+		s.levels[h] = append(s.levels[h], lvl...)
+		s.size += len(lvl)
+	}
+	for s.size >= s.maxSize {
+		s.compress()
+	}
+}
+
+// Count returns the number of values recorded, NaNs excluded.
+func (s *Sketch) Count() int { return s.n }
+
+// Quantile returns an estimate of the q-quantile of the recorded values: a
+// recorded value whose rank is approximately q·n. Quantile(0) and
+// Quantile(1) return the exact minimum and maximum. It returns NaN when the
+// sketch is empty or q is outside [0, 1].
+func (s *Sketch) Quantile(q float64) float64 {
+	return s.Quantiles(q)[0]
+}
+
+// Quantiles returns Quantile(q) for every q in qs, in the order given,
+// sharing the work of preparing the sketch for queries.
+func (s *Sketch) Quantiles(qs ...float64) []float64 {
+	out := make([]float64, len(qs))
+	items := s.weighted()
+	for i, q := range qs {
+		switch {
+		case s.n == 0 || !(q >= 0 && q <= 1):
+			out[i] = math.NaN()
+		case q == 0:
+			out[i] = s.min
+		case q == 1:
+			out[i] = s.max
+		default:
+			// The first value whose cumulative weight reaches q·n.
+			target := q * float64(s.n)
+			j, _ := slices.BinarySearchFunc(items, target, func(it weightedValue, t float64) int {
+				return cmp.Compare(float64(it.cum), t)
+			})
+			out[i] = items[min(j, len(items)-1)].v
+		}
+	}
+	return out
+}
+
+// Rank returns an estimate of the fraction of recorded values that are less
+// than or equal to x, or NaN when the sketch is empty. It is the inverse of
+// Quantile and carries the same error bound.
+func (s *Sketch) Rank(x float64) float64 {
+	if s.n == 0 {
+		return math.NaN()
+	}
+	w := 0
+	for h, lvl := range s.levels {
+		for _, v := range lvl {
+			if v <= x {
+				w += 1 << h
+			}
+		}
+	}
+	return float64(w) / float64(s.n)
+}
+
+// weightedValue is a retained value with the cumulative weight of all
+// retained values up to and including it in sorted order.
+type weightedValue struct {
+	v   float64
+	cum int
+}
+
+func (s *Sketch) weighted() []weightedValue {
+	items := make([]weightedValue, 0, s.size)
+	for h, lvl := range s.levels {
+		for _, v := range lvl {
+			items = append(items, weightedValue{v, 1 << h})
+		}
+	}
+	slices.SortFunc(items, func(a, b weightedValue) int { return cmp.Compare(a.v, b.v) })
+	cum := 0
+	for i := range items {
+		cum += items[i].cum
+		items[i].cum = cum
+	}
+	return items
+}
+
+func (s *Sketch) init() {
+	if s.k == 0 {
+		s.k = DefaultSketchK
+	}
+	if s.rng == nil {
+		s.rng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	if s.levels == nil {
+		s.grow()
+	}
+}
+
+// capacity returns the size at which level h is compacted. Capacities shrink
+// geometrically by a factor of 2/3 from the top level down, never below 2.
+func (s *Sketch) capacity(h int) int {
+	depth := len(s.levels) - h - 1
+	return int(math.Ceil(math.Pow(2.0/3, float64(depth))*float64(s.k))) + 1
+}
+
+// grow adds a level on top and recomputes the total capacity.
+func (s *Sketch) grow() {
+	s.levels = append(s.levels, nil)
+	s.maxSize = 0
+	for h := range s.levels {
+		s.maxSize += s.capacity(h)
+	}
+}
+
+// compress compacts the lowest level that has reached its capacity: it sorts
+// the level and promotes one value out of every adjacent pair, chosen by a
+// single coin flip, to the next level. With an odd count, the smallest value
+// stays behind.
+func (s *Sketch) compress() {
+	for h := range s.levels {
+		lvl := s.levels[h]
+		if len(lvl) < s.capacity(h) {
+			continue
+		}
+		if h+1 == len(s.levels) {
+			s.grow()
+		}
+		slices.Sort(lvl)
+		keep := len(lvl) % 2
+		for i := keep + s.rng.IntN(2); i < len(lvl); i += 2 {
+			s.levels[h+1] = append(s.levels[h+1], lvl[i])
+		}
+		s.size -= (len(lvl) - keep) / 2
+		s.levels[h] = lvl[:keep]
+		return
+	}
+}
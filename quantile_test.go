@@ -0,0 +1,167 @@
+package xiter
+
+import (
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestMedian(t *testing.T) {
+	if got, ok := Median(seqOf(3, 1, 4, 1, 5)); got != 3 || !ok {
+		t.Fatalf("got (%v, %v), want (3, true)", got, ok)
+	}
+	if got, ok := Median(seqOf(4, 1, 3, 2)); got != 2.5 || !ok {
+		t.Fatalf("got (%v, %v), want (2.5, true)", got, ok)
+	}
+	if _, ok := Median(Empty[int]()); ok {
+		t.Fatal("empty reported a median")
+	}
+}
+
+func TestQuantiles(t *testing.T) {
+	got, ok := Quantiles(Range2(1, 101), 0.99, 0, 0.5, 1, 0.9)
+	if want := []float64{99.01, 1, 50.5, 100, 90.1}; !ok || !approxSlice(got, want, 1e-9) {
+		t.Fatalf("got (%v, %v), want %v", got, ok, want)
+	}
+	got, _ = Quantiles(seqOf(1.0, 2.0), -0.1, 1.5, math.NaN())
+	for _, v := range got {
+		if !math.IsNaN(v) {
+			t.Fatalf("out-of-range q got %v, want NaN", got)
+		}
+	}
+	if got, ok := Quantiles(Empty[int](), 0.5); got != nil || ok {
+		t.Fatalf("empty got (%v, %v)", got, ok)
+	}
+
+	// Check selection against sorting on inputs with many duplicates.
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 50 {
+		xs := make([]int, 1+r.IntN(500))
+		for i := range xs {
+			xs[i] = r.IntN(50)
+		}
+		qs := []float64{r.Float64(), 0.5, r.Float64(), 0.1, r.Float64()}
+		got, _ := Quantiles(slices.Values(xs), qs...)
+		slices.Sort(xs)
+		for i, q := range qs {
+			h := q * float64(len(xs)-1)
+			j := int(h)
+			want := float64(xs[j])
+			if j+1 < len(xs) {
+				want += (h - float64(j)) * float64(xs[j+1]-xs[j])
+			}
+			if math.Abs(got[i]-want) > 1e-9 {
+				t.Fatalf("q=%v on %v got %v, want %v", q, xs, got[i], want)
+			}
+		}
+	}
+}
+
+func approxSlice(got, want []float64, tol float64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if math.Abs(got[i]-want[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+// seededSketch returns a sketch whose compactions are reproducible.
+func seededSketch(k int) *Sketch {
+	sk := NewSketch(k)
+	sk.rng = rand.New(rand.NewPCG(3, 4))
+	return sk
+}
+
+// checkRankError fails if the estimated quantiles of sk, which holds a
+// permutation of 0..n-1, are further than the documented 1.7/k from their
+// true ranks.
+func checkRankError(t *testing.T, sk *Sketch, n, k int) {
+	t.Helper()
+	bound := 1.7 / float64(k)
+	qs := []float64{0.001, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999}
+	for i, v := range sk.Quantiles(qs...) {
+		rank := (v + 1) / float64(n)
+		if math.Abs(rank-qs[i]) > bound {
+			t.Errorf("q=%v: estimate %v has rank %v, off by more than %v", qs[i], v, rank, bound)
+		}
+		if r := sk.Rank(v); math.Abs(r-rank) > bound {
+			t.Errorf("Rank(%v) = %v, want about %v", v, r, rank)
+		}
+	}
+}
+
+func TestSketch(t *testing.T) {
+	const n = 1_000_000
+	r := rand.New(rand.NewPCG(5, 6))
+	for _, k := range []int{50, 200, 1000} {
+		sk := seededSketch(k)
+		for _, v := range r.Perm(n) {
+			sk.Add(float64(v))
+		}
+		if sk.Count() != n {
+			t.Fatalf("Count got %d, want %d", sk.Count(), n)
+		}
+		// Retained values stay within 3k, plus two per level for rounding.
+		if sk.size > 3*k+2*len(sk.levels) {
+			t.Fatalf("k=%d retains %d values", k, sk.size)
+		}
+		checkRankError(t, sk, n, k)
+		if lo, hi := sk.Quantile(0), sk.Quantile(1); lo != 0 || hi != n-1 {
+			t.Fatalf("extremes got (%v, %v), want (0, %v)", lo, hi, n-1)
+		}
+	}
+}
+
+func TestSketchSmall(t *testing.T) {
+	var sk Sketch
+	if !math.IsNaN(sk.Quantile(0.5)) || !math.IsNaN(sk.Rank(1)) {
+		t.Fatal("empty sketch reported a quantile")
+	}
+	for _, v := range []float64{5, math.NaN(), 1, 3} {
+		sk.Add(v)
+	}
+	// Below capacity the sketch is exact.
+	if got := sk.Quantiles(0, 0.5, 1, 2); !reflect.DeepEqual(got[:3], []float64{1, 3, 5}) || !math.IsNaN(got[3]) {
+		t.Fatalf("got %v, want [1 3 5 NaN]", got)
+	}
+	if sk.Count() != 3 {
+		t.Fatalf("Count got %d, want 3 (NaN ignored)", sk.Count())
+	}
+	if got := ToSketch(seqOf(2, 9, 4), 0).Quantile(0.5); got != 4 {
+		t.Fatalf("ToSketch median got %v, want 4", got)
+	}
+}
+
+func TestSketchMerge(t *testing.T) {
+	const n, shards, k = 800_000, 8, 200
+	r := rand.New(rand.NewPCG(7, 8))
+	parts := make([]*Sketch, shards)
+	for i := range parts {
+		parts[i] = seededSketch(k)
+	}
+	for i, v := range r.Perm(n) {
+		parts[i%shards].Add(float64(v))
+	}
+	merged := seededSketch(k)
+	for _, p := range parts {
+		merged.Merge(p)
+	}
+	merged.Merge(nil)
+	merged.Merge(NewSketch(k))
+	if merged.Count() != n {
+		t.Fatalf("Count got %d, want %d", merged.Count(), n)
+	}
+	if merged.size > 3*k+2*len(merged.levels) {
+		t.Fatalf("merged sketch retains %d values", merged.size)
+	}
+	checkRankError(t, merged, n, k)
+	if before := parts[0].Count(); before != n/shards {
+		t.Fatalf("Merge changed its argument: count %d", before)
+	}
+}
@@ -0,0 +1,207 @@
+package xiter
+
+import (
+	"iter"
+	"math"
+	"math/rand/v2"
+)
+
+// ============================================================================
+// Sampling
+// ============================================================================
+
+// Sample returns k elements chosen uniformly at random from s, without
+// replacement, in a single pass and O(k) memory. It uses reservoir sampling
+// with Li's Algorithm L, which draws random numbers only for the elements it
+// keeps rather than for every element, so sampling a long stream costs little
+// more than iterating it. The result is in no particular order; when s has k
+// elements or fewer, all of them are returned in order. Returns nil when
+// k <= 0 or s is empty.
+//
+// Randomness comes from rng, so a seeded generator gives reproducible
+// samples; a nil rng uses the top-level generator of math/rand/v2.
+//
+//	rng := rand.New(rand.NewPCG(1, 2))
+//	Sample(Range1(1_000_000), 5, rng)  // 5 random elements
+func Sample[E any](s iter.Seq[E], k int, rng *rand.Rand) []E {
+	if k <= 0 {
+		return nil
+	}
+	rng = orGlobal(rng)
+	var res []E
+	// w is the largest of k uniform keys; the next element whose key beats
+	// it is skip elements away.
+	w := 1.0
+	skip := 0
+	for e := range s {
+		if len(res) < k {
+			res = append(res, e)
+			if len(res) == k {
+				w = math.Exp(math.Log(uniform(rng)) / float64(k))
+				skip = geometricSkip(rng, w)
+			}
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		res[rng.IntN(k)] = e
+		w *= math.Exp(math.Log(uniform(rng)) / float64(k))
+		skip = geometricSkip(rng, w)
+	}
+	return res
+}
+
+// Sample2 is the iter.Seq2 variant of Sample: it returns k pairs of s chosen
+// uniformly at random, as parallel key and value slices.
+func Sample2[K, V any](s iter.Seq2[K, V], k int, rng *rand.Rand) ([]K, []V) {
+	return unzipSlice(Sample(zipPairs(s), k, rng))
+}
+
+// Bernoulli yields each element of s independently with probability p,
+// which thins a sequence without the bias that StepBy introduces on periodic
+// data. The number of elements yielded is random, p·n on average. When
+// p <= 0 the result is empty, and when p >= 1 it is s itself.
+//
+// Randomness comes from rng, which is used on every iteration; a nil rng
+// uses the top-level generator of math/rand/v2.
+//
+//	Bernoulli(requests, 0.01, rng)  // about 1% of requests
+func Bernoulli[E any](s iter.Seq[E], p float64, rng *rand.Rand) iter.Seq[E] {
+	if !(p > 0) {
+		return Empty[E]()
+	}
+	if p >= 1 {
+		return s
+	}
+	rng = orGlobal(rng)
+	return func(yield func(E) bool) {
+		for e := range s {
+			if rng.Float64() < p && !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Bernoulli2 is the iter.Seq2 variant of Bernoulli: it yields each pair of s
+// independently with probability p.
+func Bernoulli2[K, V any](s iter.Seq2[K, V], p float64, rng *rand.Rand) iter.Seq2[K, V] {
+	if !(p > 0) {
+		return Empty2[K, V]()
+	}
+	if p >= 1 {
+		return s
+	}
+	rng = orGlobal(rng)
+	return func(yield func(K, V) bool) {
+		for k, v := range s {
+			if rng.Float64() < p && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// SampleWeighted returns k elements of s chosen at random without
+// replacement, where an element's chance of being chosen is proportional to
+// weight(e). It makes a single pass in O(k) memory using the A-Res algorithm
+// of Efraimidis and Spirakis, calling weight once per element. Elements
+// whose weight is zero, negative or NaN are never chosen; if fewer than k
+// elements have a positive weight, all of them are returned. The result is
+// ordered from the highest sampling key to the lowest, which favors heavy
+// elements but is itself random. Returns nil when k <= 0 or nothing can be
+// chosen.
+//
+// Randomness comes from rng; a nil rng uses the top-level generator of
+// math/rand/v2.
+//
+//	SampleWeighted(slices.Values(servers), 2, func(s Server) float64 { return s.Capacity }, rng)
+func SampleWeighted[E any](s iter.Seq[E], k int, weight func(E) float64, rng *rand.Rand) []E {
+	if k <= 0 {
+		return nil
+	}
+	rng = orGlobal(rng)
+	// Each element gets the key u^(1/w) for a uniform u, kept in log form
+	// as log(u)/w; the k largest keys form the sample.
+	keyed := func(yield func(pair[float64, E]) bool) {
+		for e := range s {
+			w := weight(e)
+			if !(w > 0) {
+				continue
+			}
+			if !yield(pair[float64, E]{math.Log(uniform(rng)) / w, e}) {
+				return
+			}
+		}
+	}
+	top := topK(keyed, k, func(a, b pair[float64, E]) int {
+		switch {
+		case a.k < b.k:
+			return -1
+		case a.k > b.k:
+			return 1
+		}
+		return 0
+	})
+	if top == nil {
+		return nil
+	}
+	out := make([]E, len(top))
+	for i, kv := range top {
+		out[i] = kv.v
+	}
+	return out
+}
+
+// SampleWeighted2 is the iter.Seq2 variant of SampleWeighted: it returns k
+// pairs of s, each chosen with probability proportional to weight(k, v), as
+// parallel key and value slices.
+func SampleWeighted2[K, V any](s iter.Seq2[K, V], k int, weight func(K, V) float64, rng *rand.Rand) ([]K, []V) {
+	return unzipSlice(SampleWeighted(zipPairs(s), k, func(kv pair[K, V]) float64 { return weight(kv.k, kv.v) }, rng))
+}
+
+// uniform returns a uniform random number in (0, 1], safe to take the
+// logarithm of.
+func uniform(rng *rand.Rand) float64 {
+	return 1 - rng.Float64()
+}
+
+// geometricSkip returns how many elements Algorithm L passes over before the
+// next replacement, given the current threshold w.
+func geometricSkip(rng *rand.Rand, w float64) int {
+	skip := math.Floor(math.Log(uniform(rng)) / math.Log1p(-w))
+	if !(skip < math.MaxInt) {
+		// The gap no longer fits in an int, or w has underflowed to 0:
+		// another replacement is as good as never.
+		return math.MaxInt
+	}
+	return int(skip)
+}
+
+// unzipSlice splits pairs into parallel key and value slices, both nil when
+// kvs is.
+func unzipSlice[K, V any](kvs []pair[K, V]) ([]K, []V) {
+	if kvs == nil {
+		return nil, nil
+	}
+	ks, vs := make([]K, len(kvs)), make([]V, len(kvs))
+	for i, kv := range kvs {
+		ks[i], vs[i] = kv.k, kv.v
+	}
+	return ks, vs
+}
+
+// globalSource is a rand.Source backed by the top-level generator of
+// math/rand/v2.
+type globalSource struct{}
+
+func (globalSource) Uint64() uint64 { return rand.Uint64() }
+
+func orGlobal(rng *rand.Rand) *rand.Rand {
+	if rng == nil {
+		return rand.New(globalSource{})
+	}
+	return rng
+}
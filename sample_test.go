@@ -0,0 +1,176 @@
+package xiter
+
+import (
+	"maps"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func newRand(seed uint64) *rand.Rand { return rand.New(rand.NewPCG(seed, seed+1)) }
+
+func TestSample(t *testing.T) {
+	if got := Sample(seqOf(1, 2, 3), 5, newRand(1)); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Fatalf("short input got %v, want [1 2 3]", got)
+	}
+	if got := Sample(seqOf(1, 2, 3), 0, nil); got != nil {
+		t.Fatalf("k = 0 got %v, want nil", got)
+	}
+	if got := Sample(Empty[int](), 3, nil); got != nil {
+		t.Fatalf("empty got %v, want nil", got)
+	}
+	a := Sample(Range1(10_000), 10, newRand(2))
+	b := Sample(Range1(10_000), 10, newRand(2))
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("same seed gave %v and %v", a, b)
+	}
+	slices.Sort(a)
+	if len(slices.Compact(a)) != 10 {
+		t.Fatalf("sample %v has duplicates", a)
+	}
+	if got := Sample(Range1(100), 5, nil); len(got) != 5 {
+		t.Fatalf("nil rng got %v", got)
+	}
+}
+
+func TestSampleUniform(t *testing.T) {
+	// Every element of 0..19 should land in a 5-sample a quarter of the time.
+	const n, k, trials = 20, 5, 20_000
+	rng := newRand(3)
+	counts := make([]int, n)
+	for range trials {
+		for _, v := range Sample(Range1(n), k, rng) {
+			counts[v]++
+		}
+	}
+	want := float64(trials) * k / n
+	for v, c := range counts {
+		if math.Abs(float64(c)-want) > 0.05*want {
+			t.Fatalf("element %d chosen %d times, want about %v", v, c, want)
+		}
+	}
+
+	// On a long stream most of the work is done by skipping; each tenth of
+	// the input should still hold a tenth of the sample.
+	deciles := make([]int, 10)
+	for range 2000 {
+		for _, v := range Sample(Range1(10_000), 10, rng) {
+			deciles[v/1000]++
+		}
+	}
+	for d, c := range deciles {
+		if math.Abs(float64(c)-2000) > 0.05*2000 {
+			t.Fatalf("decile %d holds %d of 20000 samples, want about 2000", d, c)
+		}
+	}
+}
+
+func TestSample2(t *testing.T) {
+	type kv = struct {
+		K string
+		V int
+	}
+	ks, vs := Sample2(seq2Of(kv{"a", 1}, kv{"b", 2}, kv{"c", 3}), 2, newRand(4))
+	if len(ks) != 2 || len(vs) != 2 {
+		t.Fatalf("got %v, %v", ks, vs)
+	}
+	for i := range ks {
+		if int(ks[i][0]-'a'+1) != vs[i] {
+			t.Fatalf("pair (%v, %v) split apart", ks[i], vs[i])
+		}
+	}
+	if ks, vs := Sample2(Empty2[string, int](), 2, nil); ks != nil || vs != nil {
+		t.Fatalf("empty got %v, %v", ks, vs)
+	}
+}
+
+func TestBernoulli(t *testing.T) {
+	const n = 100_000
+	got := Size(Bernoulli(Range1(n), 0.1, newRand(5)))
+	if math.Abs(float64(got)-n*0.1) > 0.05*n*0.1 {
+		t.Fatalf("kept %d of %d at p=0.1", got, n)
+	}
+	if got := ToSlice(Bernoulli(Range1(5), 1, nil)); !reflect.DeepEqual(got, []int{0, 1, 2, 3, 4}) {
+		t.Fatalf("p=1 got %v", got)
+	}
+	if got := Size(Bernoulli(Range1(5), 0, nil)); got != 0 {
+		t.Fatalf("p=0 kept %d", got)
+	}
+	if got := Size(Bernoulli(Range1(5), math.NaN(), nil)); got != 0 {
+		t.Fatalf("p=NaN kept %d", got)
+	}
+	stopEarly(Bernoulli(Repeat(1), 0.5, newRand(6)))
+
+	// Elements keep their order.
+	kept := ToSlice(Bernoulli(Range1(1000), 0.3, newRand(7)))
+	if !slices.IsSorted(kept) {
+		t.Fatal("Bernoulli reordered elements")
+	}
+}
+
+func TestBernoulli2(t *testing.T) {
+	const n = 100_000
+	s := Zip(Range1(n), Range1(n))
+	got := 0
+	for k, v := range Bernoulli2(s, 0.2, newRand(8)) {
+		if k != v {
+			t.Fatalf("pair (%v, %v) split apart", k, v)
+		}
+		got++
+	}
+	if math.Abs(float64(got)-n*0.2) > 0.05*n*0.2 {
+		t.Fatalf("kept %d of %d at p=0.2", got, n)
+	}
+	if got := ToMap(Bernoulli2(Zip(Range1(3), Range1(3)), 0, nil)); len(got) != 0 {
+		t.Fatalf("p=0 got %v", got)
+	}
+	stopEarly2(Bernoulli2(s, 0.5, newRand(9)))
+}
+
+func TestSampleWeighted(t *testing.T) {
+	// Weights 1:2:3:4 with k=1 should pick each in that proportion.
+	const trials = 40_000
+	rng := newRand(10)
+	counts := map[string]int{}
+	weights := map[string]float64{"a": 1, "b": 2, "c": 3, "d": 4, "zero": 0, "neg": -1, "nan": math.NaN()}
+	items := slices.Sorted(maps.Keys(weights))
+	weight := func(s string) float64 { return weights[s] }
+	for range trials {
+		for _, v := range SampleWeighted(slices.Values(items), 1, weight, rng) {
+			counts[v]++
+		}
+	}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		want := trials * weights[name] / 10
+		if math.Abs(float64(counts[name])-want) > 0.05*want {
+			t.Fatalf("%s chosen %d times, want about %v", name, counts[name], want)
+		}
+	}
+	if n := counts["zero"] + counts["neg"] + counts["nan"]; n != 0 {
+		t.Fatalf("non-positive weights chosen %d times", n)
+	}
+
+	// Only positive weights count towards k.
+	got := SampleWeighted(slices.Values(items), 10, weight, rng)
+	slices.Sort(got)
+	if !reflect.DeepEqual(got, []string{"a", "b", "c", "d"}) {
+		t.Fatalf("got %v, want [a b c d]", got)
+	}
+	if got := SampleWeighted(slices.Values(items), 0, weight, nil); got != nil {
+		t.Fatalf("k = 0 got %v", got)
+	}
+}
+
+func TestSampleWeighted2(t *testing.T) {
+	type kv = struct {
+		K string
+		V int
+	}
+	s := seq2Of(kv{"a", 0}, kv{"b", 5}, kv{"c", 0})
+	ks, vs := SampleWeighted2(s, 2, func(_ string, v int) float64 { return float64(v) }, newRand(11))
+	if !reflect.DeepEqual(ks, []string{"b"}) || !reflect.DeepEqual(vs, []int{5}) {
+		t.Fatalf("got %v, %v, want [b], [5]", ks, vs)
+	}
+}
@@ -3,6 +3,8 @@ package xiter
 import (
 	"cmp"
 	"iter"
+	"slices"
+	"sync"
 )
 
 // ============================================================================
@@ -159,6 +161,95 @@ func Repeat[E any](e E) iter.Seq[E] {
 	}
 }
 
+// Cycle generates an infinite sequence that replays s over and over. s must
+// be re-iterable: every pass calls s again from the start. If a pass yields
+// nothing, the sequence ends instead of spinning, so cycling an empty source
+// terminates. Use CycleBuffered for single-use sources.
+//
+//	Take(Cycle(seqOf(1, 2, 3)), 7)  // yields 1, 2, 3, 1, 2, 3, 1
+func Cycle[E any](s iter.Seq[E]) iter.Seq[E] {
+	return cycle(s, -1)
+}
+
+// CycleN generates a sequence that replays s n times. When n <= 0 the
+// result is empty. Like Cycle, it calls s again for every pass and stops
+// early after a pass that yields nothing.
+//
+//	CycleN(seqOf(1, 2), 3)  // yields 1, 2, 1, 2, 1, 2
+func CycleN[E any](s iter.Seq[E], n int) iter.Seq[E] {
+	if n <= 0 {
+		return Empty[E]()
+	}
+	return cycle(s, n)
+}
+
+// cycle replays s n times, or forever when n is negative.
+func cycle[E any](s iter.Seq[E], n int) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for i := 0; n < 0 || i < n; i++ {
+			empty := true
+			for e := range s {
+				empty = false
+				if !yield(e) {
+					return
+				}
+			}
+			if empty {
+				return
+			}
+		}
+	}
+}
+
+// CycleBuffered is like Cycle but iterates s only once: the first pass
+// yields the elements of s while buffering them, and every later pass replays
+// the buffer. The buffer is shared by every iteration of the returned
+// sequence once a pass has run s to the end, so ranging over it again replays
+// from the first element instead of calling s a second time. It suits
+// single-use sources such as channels or readers, at the cost of holding
+// every element of s in memory. An empty source terminates.
+//
+// Breaking out of a pass before s is exhausted stops s like any other early
+// exit, and nothing is buffered: the next iteration starts a fresh pass over
+// s.
+//
+//	Take(CycleBuffered(seqOf(1, 2)), 5)  // yields 1, 2, 1, 2, 1
+func CycleBuffered[E any](s iter.Seq[E]) iter.Seq[E] {
+	var (
+		mu   sync.Mutex
+		rec  []E
+		full bool // rec holds every element of s
+	)
+	return func(yield func(E) bool) {
+		mu.Lock()
+		buf, ok := rec, full
+		mu.Unlock()
+		if !ok {
+			for e := range s {
+				buf = append(buf, e)
+				if !yield(e) {
+					return
+				}
+			}
+			mu.Lock()
+			if !full {
+				rec, full = buf, true
+			}
+			mu.Unlock()
+		}
+		if len(buf) == 0 {
+			return
+		}
+		for {
+			for _, e := range buf {
+				if !yield(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
 // ============================================================================
 // Transform
 // ============================================================================
@@ -354,6 +445,98 @@ func FilterMap[E1, E2 any](s iter.Seq[E1], f func(E1) (E2, bool)) iter.Seq[E2] {
 	}
 }
 
+// Distinct yields each element the first time it appears and drops later
+// duplicates, preserving the order of first occurrence. Every distinct
+// element seen so far is remembered, so memory grows with the number of
+// distinct elements; use DistinctLRU for long or infinite sequences.
+//
+//	Distinct(seqOf(1, 2, 1, 3, 2))  // yields 1, 2, 3
+func Distinct[E comparable](s iter.Seq[E]) iter.Seq[E] {
+	return DistinctBy(s, func(e E) E { return e })
+}
+
+// DistinctBy is like Distinct but compares elements by the key returned by
+// key. Of the elements sharing a key, only the first is yielded.
+//
+//	DistinctBy(seqOf("apple", "avocado", "banana"), func(s string) byte { return s[0] })
+//	// yields "apple", "banana"
+func DistinctBy[E any, K comparable](s iter.Seq[E], key func(E) K) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		seen := make(map[K]struct{})
+		for e := range s {
+			k := key(e)
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// DistinctLRU is a bounded-memory variant of Distinct that remembers only the
+// size most recently seen distinct elements. An element is dropped when it is
+// among them; seeing it again refreshes its recency. Duplicates further apart
+// than size distinct elements are yielded again. When size <= 0 nothing is
+// remembered and every element is yielded.
+//
+//	DistinctLRU(seqOf(1, 2, 1, 3, 1, 2), 2)  // yields 1, 2, 3, 2
+func DistinctLRU[E comparable](s iter.Seq[E], size int) iter.Seq[E] {
+	return DistinctByLRU(s, func(e E) E { return e }, size)
+}
+
+// DistinctByLRU is like DistinctLRU but compares elements by the key returned
+// by key.
+func DistinctByLRU[E any, K comparable](s iter.Seq[E], key func(E) K, size int) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		if size <= 0 {
+			for e := range s {
+				if !yield(e) {
+					return
+				}
+			}
+			return
+		}
+		seen := newLRUSet[K](size)
+		for e := range s {
+			if !seen.seen(key(e)) && !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Dedup drops elements equal to the element immediately before them, so that
+// each run of consecutive duplicates collapses to its first element. Unlike
+// Distinct it needs no memory beyond the previous element.
+//
+//	Dedup(seqOf(1, 1, 2, 2, 1))  // yields 1, 2, 1
+func Dedup[E comparable](s iter.Seq[E]) iter.Seq[E] {
+	return DedupFunc(s, func(a, b E) bool { return a == b })
+}
+
+// DedupFunc is like Dedup but uses eq to decide whether an element duplicates
+// the one before it. eq is called with the most recently yielded element and
+// the current one, so a run is compared against its first element.
+func DedupFunc[E any](s iter.Seq[E], eq func(prev, cur E) bool) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		var prev E
+		first := true
+		for e := range s {
+			if !first && eq(prev, e) {
+				continue
+			}
+			first = false
+			prev = e
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
 // Take yields the first n elements, then stops. When n <= 0 the result is
 // empty. When the source has fewer than n elements, all of them are yielded.
 //
@@ -455,6 +638,148 @@ func StepBy[E any](s iter.Seq[E], n int) iter.Seq[E] {
 	}
 }
 
+// Chunks groups consecutive elements into slices of n elements each. The last
+// chunk holds the remaining elements and may be shorter than n. When n <= 0
+// the result is empty.
+//
+// Every yielded chunk is a freshly allocated slice that the consumer may
+// retain or modify. Use ChunksReuse to avoid the per-chunk allocation.
+//
+//	Chunks(Range1(5), 2)  // yields [0 1], [2 3], [4]
+func Chunks[E any](s iter.Seq[E], n int) iter.Seq[[]E] {
+	return chunks(s, n, false)
+}
+
+// ChunksReuse is like Chunks but yields the same backing buffer for every
+// chunk. A yielded slice is only valid until the next iteration step; copy it
+// (e.g. with slices.Clone) to retain it. It performs no per-chunk allocation.
+func ChunksReuse[E any](s iter.Seq[E], n int) iter.Seq[[]E] {
+	return chunks(s, n, true)
+}
+
+func chunks[E any](s iter.Seq[E], n int, reuse bool) iter.Seq[[]E] {
+	return func(yield func([]E) bool) {
+		if n <= 0 {
+			return
+		}
+		buf := make([]E, 0, n)
+		for e := range s {
+			buf = append(buf, e)
+			if len(buf) < n {
+				continue
+			}
+			if !yield(buf) {
+				return
+			}
+			if reuse {
+				buf = buf[:0]
+			} else {
+				buf = make([]E, 0, n)
+			}
+		}
+		if len(buf) > 0 {
+			yield(buf)
+		}
+	}
+}
+
+// Windows yields sliding windows of exactly size consecutive elements, with
+// the start of each window advancing by step elements. Trailing elements that
+// cannot fill a whole window are not yielded. When step > size, the elements
+// between windows are skipped. When size <= 0 or step <= 0 the result is
+// empty.
+//
+// Every yielded window is a freshly allocated slice that the consumer may
+// retain or modify. Use WindowsReuse to avoid the per-window allocation.
+//
+//	Windows(Range1(5), 3, 1)  // yields [0 1 2], [1 2 3], [2 3 4]
+//	Windows(Range1(6), 2, 3)  // yields [0 1], [3 4]
+func Windows[E any](s iter.Seq[E], size, step int) iter.Seq[[]E] {
+	return windows(s, size, step, false)
+}
+
+// WindowsReuse is like Windows but yields the same backing buffer for every
+// window. A yielded slice is only valid until the next iteration step; copy
+// it (e.g. with slices.Clone) to retain it.
+func WindowsReuse[E any](s iter.Seq[E], size, step int) iter.Seq[[]E] {
+	return windows(s, size, step, true)
+}
+
+func windows[E any](s iter.Seq[E], size, step int, reuse bool) iter.Seq[[]E] {
+	return func(yield func([]E) bool) {
+		if size <= 0 || step <= 0 {
+			return
+		}
+		buf := make([]E, 0, size)
+		skip := 0
+		for e := range s {
+			if skip > 0 {
+				skip--
+				continue
+			}
+			buf = append(buf, e)
+			if len(buf) < size {
+				continue
+			}
+			out := buf
+			if !reuse {
+				out = slices.Clone(buf)
+			}
+			if !yield(out) {
+				return
+			}
+			if step < size {
+				buf = buf[:copy(buf, buf[step:])]
+			} else {
+				buf = buf[:0]
+				skip = step - size
+			}
+		}
+	}
+}
+
+// ChunkBy groups runs of consecutive elements into slices. f is called with
+// each pair of adjacent elements and reports whether they belong to the same
+// chunk; a new chunk starts whenever it returns false.
+//
+// Every yielded chunk is a freshly allocated slice that the consumer may
+// retain or modify. Use ChunkByReuse to avoid the per-chunk allocation.
+//
+//	ChunkBy(seqOf(1, 1, 2, 3, 3), func(prev, cur int) bool { return prev == cur })
+//	// yields [1 1], [2], [3 3]
+func ChunkBy[E any](s iter.Seq[E], f func(prev, cur E) bool) iter.Seq[[]E] {
+	return chunkBy(s, f, false)
+}
+
+// ChunkByReuse is like ChunkBy but yields the same backing buffer for every
+// chunk. A yielded slice is only valid until the next iteration step; copy it
+// (e.g. with slices.Clone) to retain it.
+func ChunkByReuse[E any](s iter.Seq[E], f func(prev, cur E) bool) iter.Seq[[]E] {
+	return chunkBy(s, f, true)
+}
+
+func chunkBy[E any](s iter.Seq[E], f func(prev, cur E) bool, reuse bool) iter.Seq[[]E] {
+	return func(yield func([]E) bool) {
+		var buf []E
+		for e := range s {
+			if len(buf) > 0 && !f(buf[len(buf)-1], e) {
+				if !yield(buf) {
+					return
+				}
+				if reuse {
+					buf = buf[:0]
+				} else {
+					buf = nil
+				}
+			}
+			buf = append(buf, e)
+		}
+		if len(buf) > 0 {
+			yield(buf)
+		}
+	}
+}
+
 // Chain concatenates seq1 and seq2 into a single sequence: all elements of
 // seq1 first, then all elements of seq2. When yield returns false, iteration
 // of the current source stops immediately and the other source is never
@@ -476,6 +801,120 @@ func Chain[E any](seq1, seq2 iter.Seq[E]) iter.Seq[E] {
 	}
 }
 
+// Concat concatenates any number of sequences into one: all elements of the
+// first, then all elements of the second, and so on. It generalizes Chain.
+// When yield returns false, the remaining sources are never consumed.
+//
+//	Concat(Range1(2), Once(5), Range2(10, 12))  // yields 0, 1, 5, 10, 11
+func Concat[E any](seqs ...iter.Seq[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for _, s := range seqs {
+			for e := range s {
+				if !yield(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Interleave takes one element from each sequence in turn, round-robin.
+// A sequence that runs out is dropped from the rotation and the others
+// continue, so every element of every source is yielded exactly once.
+// Sources are pulled with iter.Pull and released when iteration ends.
+//
+//	Interleave(seqOf(1, 2, 3), seqOf(10), seqOf(20, 21))
+//	// yields 1, 10, 20, 2, 21, 3
+func Interleave[E any](seqs ...iter.Seq[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		nexts := make([]func() (E, bool), 0, len(seqs))
+		for _, s := range seqs {
+			next, stop := iter.Pull(s)
+			defer stop()
+			nexts = append(nexts, next)
+		}
+		for len(nexts) > 0 {
+			live := nexts[:0]
+			for _, next := range nexts {
+				e, ok := next()
+				if !ok {
+					continue
+				}
+				if !yield(e) {
+					return
+				}
+				live = append(live, next)
+			}
+			nexts = live
+		}
+	}
+}
+
+// InterleaveShortest is like Interleave but yields whole rounds only: each
+// round pulls one element from every sequence, and iteration ends at the
+// first round in which any sequence runs out. Elements pulled in that final,
+// incomplete round are discarded, so every source contributes the same number
+// of elements. Because a round is yielded only once it is complete, each
+// round's elements are buffered before the first of them is yielded.
+//
+//	InterleaveShortest(seqOf(1, 2, 3), seqOf(10), seqOf(20, 21))
+//	// yields 1, 10, 20
+func InterleaveShortest[E any](seqs ...iter.Seq[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		if len(seqs) == 0 {
+			return
+		}
+		nexts := make([]func() (E, bool), 0, len(seqs))
+		for _, s := range seqs {
+			next, stop := iter.Pull(s)
+			defer stop()
+			nexts = append(nexts, next)
+		}
+		round := make([]E, len(nexts))
+		for {
+			for i, next := range nexts {
+				e, ok := next()
+				if !ok {
+					return
+				}
+				round[i] = e
+			}
+			for _, e := range round {
+				if !yield(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Intersperse yields the elements of s with sep inserted between each pair of
+// adjacent elements. No separator precedes the first element or follows the
+// last.
+//
+//	Intersperse(seqOf("a", "b", "c"), ",")  // yields "a", ",", "b", ",", "c"
+func Intersperse[E any](s iter.Seq[E], sep E) iter.Seq[E] {
+	return IntersperseWith(s, func() E { return sep })
+}
+
+// IntersperseWith is like Intersperse but calls f for every separator, which
+// suits separators that must be distinct values, such as freshly allocated
+// tokens. f is only called when another element follows.
+func IntersperseWith[E any](s iter.Seq[E], f func() E) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		first := true
+		for e := range s {
+			if !first && !yield(f()) {
+				return
+			}
+			first = false
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
 // Zip pairs elements from x and y position-by-position, yielding (x_i, y_i)
 // pairs. Iteration stops as soon as either sequence is exhausted; excess
 // elements of the longer sequence are never consumed.
@@ -92,6 +92,56 @@ func Repeat2[K, V any](k K, v V) iter.Seq2[K, V] {
 	}
 }
 
+// Cycle2 is the iter.Seq2 variant of Cycle: it replays the re-iterable s
+// forever and ends after a pass that yields nothing.
+//
+//	Take2(Cycle2(maps.All(map[string]int{"a": 1})), 2)  // yields ("a",1), ("a",1)
+func Cycle2[K, V any](s iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return cycle2(s, -1)
+}
+
+// CycleN2 is the iter.Seq2 variant of CycleN: it replays s n times. When
+// n <= 0 the result is empty.
+func CycleN2[K, V any](s iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	if n <= 0 {
+		return Empty2[K, V]()
+	}
+	return cycle2(s, n)
+}
+
+// cycle2 replays s n times, or forever when n is negative.
+func cycle2[K, V any](s iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for i := 0; n < 0 || i < n; i++ {
+			empty := true
+			for k, v := range s {
+				empty = false
+				if !yield(k, v) {
+					return
+				}
+			}
+			if empty {
+				return
+			}
+		}
+	}
+}
+
+// CycleBuffered2 is the iter.Seq2 variant of CycleBuffered: it iterates s
+// once, buffering its pairs in a buffer shared by every iteration, and
+// replays the buffer forever afterwards. As with CycleBuffered, a pass that
+// breaks out early stops s and buffers nothing.
+func CycleBuffered2[K, V any](s iter.Seq2[K, V]) iter.Seq2[K, V] {
+	c := CycleBuffered(zipPairs(s))
+	return func(yield func(K, V) bool) {
+		for p := range c {
+			if !yield(p.k, p.v) {
+				return
+			}
+		}
+	}
+}
+
 // ============================================================================
 // Transform
 // ============================================================================
@@ -220,6 +270,95 @@ func FilterMap2[K1, V1, K2, V2 any](s iter.Seq2[K1, V1], f func(K1, V1) (K2, V2,
 	}
 }
 
+// Distinct2 yields the first pair for each key and drops later pairs with a
+// key already seen, preserving the order of first occurrence. Every distinct
+// key seen so far is remembered; use DistinctLRU2 for long or infinite
+// sequences.
+//
+//	Distinct2(seq2Of(("a",1), ("b",2), ("a",3)))  // yields ("a",1), ("b",2)
+func Distinct2[K comparable, V any](s iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return DistinctBy2(s, func(k K, _ V) K { return k })
+}
+
+// DistinctBy2 is like Distinct2 but compares pairs by the key returned by
+// key, which may be derived from both the key and the value. Of the pairs
+// sharing a key, only the first is yielded.
+func DistinctBy2[K, V any, C comparable](s iter.Seq2[K, V], key func(K, V) C) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		seen := make(map[C]struct{})
+		for k, v := range s {
+			c := key(k, v)
+			if _, ok := seen[c]; ok {
+				continue
+			}
+			seen[c] = struct{}{}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// DistinctLRU2 is a bounded-memory variant of Distinct2 that remembers only
+// the size most recently seen keys. A pair is dropped when its key is among
+// them; seeing the key again refreshes its recency. When size <= 0 nothing is
+// remembered and every pair is yielded.
+func DistinctLRU2[K comparable, V any](s iter.Seq2[K, V], size int) iter.Seq2[K, V] {
+	return DistinctByLRU2(s, func(k K, _ V) K { return k }, size)
+}
+
+// DistinctByLRU2 is like DistinctLRU2 but compares pairs by the key returned
+// by key.
+func DistinctByLRU2[K, V any, C comparable](s iter.Seq2[K, V], key func(K, V) C, size int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if size <= 0 {
+			for k, v := range s {
+				if !yield(k, v) {
+					return
+				}
+			}
+			return
+		}
+		seen := newLRUSet[C](size)
+		for k, v := range s {
+			if !seen.seen(key(k, v)) && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Dedup2 drops pairs whose key equals the key of the pair immediately before
+// them, so that each run of consecutive equal keys collapses to its first
+// pair.
+//
+//	Dedup2(seq2Of(("a",1), ("a",2), ("b",3), ("a",4)))
+//	// yields ("a",1), ("b",3), ("a",4)
+func Dedup2[K comparable, V any](s iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return DedupFunc2(s, func(k1 K, _ V, k2 K, _ V) bool { return k1 == k2 })
+}
+
+// DedupFunc2 is like Dedup2 but uses eq to decide whether a pair duplicates
+// the one before it. eq is called with the most recently yielded pair and the
+// current one, so a run is compared against its first pair.
+func DedupFunc2[K, V any](s iter.Seq2[K, V], eq func(prevK K, prevV V, curK K, curV V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var prevK K
+		var prevV V
+		first := true
+		for k, v := range s {
+			if !first && eq(prevK, prevV, k, v) {
+				continue
+			}
+			first = false
+			prevK, prevV = k, v
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
 // Take2 yields the first n pairs, then stops. When n <= 0 the result is
 // empty. When the source has fewer than n pairs, all of them are yielded.
 //
@@ -318,6 +457,87 @@ func StepBy2[K, V any](s iter.Seq2[K, V], n int) iter.Seq2[K, V] {
 	}
 }
 
+// Chunks2 groups consecutive pairs into chunks of n pairs each, yielding the
+// keys and values of every chunk as two parallel slices of equal length. The
+// last chunk may be shorter than n. When n <= 0 the result is empty. The
+// yielded slices are freshly allocated; use ChunksReuse2 to avoid that.
+//
+//	Chunks2(Enumerate(seqOf("a", "b", "c")), 2)
+//	// yields ([0 1],["a" "b"]), ([2],["c"])
+func Chunks2[K, V any](s iter.Seq2[K, V], n int) iter.Seq2[[]K, []V] {
+	return unzipChunks(chunks(zipPairs(s), n, true), false)
+}
+
+// ChunksReuse2 is like Chunks2 but yields the same two backing buffers for
+// every chunk. The yielded slices are only valid until the next iteration
+// step.
+func ChunksReuse2[K, V any](s iter.Seq2[K, V], n int) iter.Seq2[[]K, []V] {
+	return unzipChunks(chunks(zipPairs(s), n, true), true)
+}
+
+// Windows2 is the iter.Seq2 variant of Windows: it yields sliding windows of
+// exactly size pairs, advancing by step pairs, as parallel key and value
+// slices. When size <= 0 or step <= 0 the result is empty. The yielded slices
+// are freshly allocated; use WindowsReuse2 to avoid that.
+func Windows2[K, V any](s iter.Seq2[K, V], size, step int) iter.Seq2[[]K, []V] {
+	return unzipChunks(windows(zipPairs(s), size, step, true), false)
+}
+
+// WindowsReuse2 is like Windows2 but yields the same two backing buffers for
+// every window. The yielded slices are only valid until the next iteration
+// step.
+func WindowsReuse2[K, V any](s iter.Seq2[K, V], size, step int) iter.Seq2[[]K, []V] {
+	return unzipChunks(windows(zipPairs(s), size, step, true), true)
+}
+
+// ChunkBy2 is the iter.Seq2 variant of ChunkBy: f is called with each pair of
+// adjacent entries and reports whether they belong to the same chunk. Chunks
+// are yielded as parallel key and value slices, freshly allocated; use
+// ChunkByReuse2 to avoid that.
+func ChunkBy2[K, V any](s iter.Seq2[K, V], f func(prevK K, prevV V, curK K, curV V) bool) iter.Seq2[[]K, []V] {
+	return unzipChunks(chunkBy(zipPairs(s), pairFunc(f), true), false)
+}
+
+// ChunkByReuse2 is like ChunkBy2 but yields the same two backing buffers for
+// every chunk. The yielded slices are only valid until the next iteration
+// step.
+func ChunkByReuse2[K, V any](s iter.Seq2[K, V], f func(prevK K, prevV V, curK K, curV V) bool) iter.Seq2[[]K, []V] {
+	return unzipChunks(chunkBy(zipPairs(s), pairFunc(f), true), true)
+}
+
+// zipPairs packs each key/value pair of s into a pair value.
+func zipPairs[K, V any](s iter.Seq2[K, V]) iter.Seq[pair[K, V]] {
+	return Join(s, func(k K, v V) pair[K, V] { return pair[K, V]{k, v} })
+}
+
+// pairFunc adapts a four-argument Seq2 predicate to compare two pairs.
+func pairFunc[K, V any](f func(K, V, K, V) bool) func(pair[K, V], pair[K, V]) bool {
+	return func(a, b pair[K, V]) bool { return f(a.k, a.v, b.k, b.v) }
+}
+
+// unzipChunks splits each chunk of pairs into parallel key and value slices,
+// either into fresh slices or into two buffers reused across chunks.
+func unzipChunks[K, V any](s iter.Seq[[]pair[K, V]], reuse bool) iter.Seq2[[]K, []V] {
+	return func(yield func([]K, []V) bool) {
+		var ks []K
+		var vs []V
+		for ps := range s {
+			if reuse {
+				ks, vs = ks[:0], vs[:0]
+			} else {
+				ks, vs = make([]K, 0, len(ps)), make([]V, 0, len(ps))
+			}
+			for _, p := range ps {
+				ks = append(ks, p.k)
+				vs = append(vs, p.v)
+			}
+			if !yield(ks, vs) {
+				return
+			}
+		}
+	}
+}
+
 // Chain2 concatenates seq1 and seq2 into a single key/value sequence: all
 // pairs of seq1 first, then all pairs of seq2. When yield returns false,
 // iteration of the current source stops immediately and the other source is
@@ -339,6 +559,24 @@ func Chain2[K, V any](seq1, seq2 iter.Seq2[K, V]) iter.Seq2[K, V] {
 	}
 }
 
+// Concat2 concatenates any number of key/value sequences into one, in order.
+// It generalizes Chain2. When yield returns false, the remaining sources are
+// never consumed.
+//
+//	Concat2(Once2("a", 1), Empty2[string, int](), Once2("b", 2))
+//	// yields ("a",1), ("b",2)
+func Concat2[K, V any](seqs ...iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, s := range seqs {
+			for k, v := range s {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
 // ============================================================================
 // Terminal
 // ============================================================================
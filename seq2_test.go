@@ -167,6 +167,57 @@ func TestRepeat2(t *testing.T) {
 	}
 }
 
+func TestCycle2(t *testing.T) {
+	type kv = struct {
+		K string
+		V int
+	}
+	src := seq2Of(kv{"a", 1}, kv{"b", 2})
+	var ks []string
+	var vs []int
+	for k, v := range Take2(Cycle2(src), 5) {
+		ks = append(ks, k)
+		vs = append(vs, v)
+	}
+	if !reflect.DeepEqual(ks, []string{"a", "b", "a", "b", "a"}) || !reflect.DeepEqual(vs, []int{1, 2, 1, 2, 1}) {
+		t.Fatalf("got %v %v", ks, vs)
+	}
+	if n := Size2(CycleN2(src, 3)); n != 6 {
+		t.Fatalf("CycleN2 yielded %d pairs, want 6", n)
+	}
+	if n := Size2(CycleN2(src, 0)); n != 0 {
+		t.Fatalf("CycleN2 with n=0 yielded %d pairs, want 0", n)
+	}
+	if n := Size2(Cycle2(Empty2[string, int]())); n != 0 {
+		t.Fatalf("Cycle2 of empty yielded %d pairs, want 0", n)
+	}
+	if n := Size2(CycleBuffered2(Empty2[string, int]())); n != 0 {
+		t.Fatalf("CycleBuffered2 of empty yielded %d pairs, want 0", n)
+	}
+	vs = nil
+	for _, v := range Take2(CycleBuffered2(src), 3) {
+		vs = append(vs, v)
+	}
+	if !reflect.DeepEqual(vs, []int{1, 2, 1}) {
+		t.Fatalf("CycleBuffered2 got %v, want [1 2 1]", vs)
+	}
+	released := false
+	early := func(yield func(string, int) bool) {
+		defer func() { released = true }()
+		for k, v := range src {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+	if n := Size2(Take2(CycleBuffered2(early), 1)); n != 1 || !released {
+		t.Fatalf("CycleBuffered2 yielded %d pairs, released %v; want 1, true", n, released)
+	}
+	stopEarly2(Cycle2(src))
+	stopEarly2(CycleN2(src, 2))
+	stopEarly2(CycleBuffered2(src))
+}
+
 // ============================================================================
 // Transform
 // ============================================================================
@@ -715,6 +766,17 @@ func TestChain2(t *testing.T) {
 	}
 }
 
+func TestConcat2(t *testing.T) {
+	got := ToMap(Concat2(Once2("a", 1), Empty2[string, int](), Once2("b", 2), Once2("c", 3)))
+	if want := map[string]int{"a": 1, "b": 2, "c": 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if n := Size2(Concat2[string, int]()); n != 0 {
+		t.Fatalf("got %d pairs, want 0", n)
+	}
+	stopEarly2(Concat2(Once2("a", 1), Once2("b", 2)))
+}
+
 func TestForEach2(t *testing.T) {
 	sum := 0
 	ForEach2(Enumerate(Range1(3)), func(k, v int) { sum += k + v })
@@ -990,3 +1052,160 @@ func TestGeneratorsEarlyStop2(t *testing.T) {
 	stopEarly(Keys(Enumerate(Range1(10))))
 	stopEarly(Values(Enumerate(Range1(10))))
 }
+
+func TestChunks2(t *testing.T) {
+	var ks [][]int
+	var vs [][]string
+	for k, v := range Chunks2(Enumerate(seqOf("a", "b", "c", "d", "e")), 2) {
+		ks = append(ks, k)
+		vs = append(vs, v)
+	}
+	if !reflect.DeepEqual(ks, [][]int{{0, 1}, {2, 3}, {4}}) {
+		t.Fatalf("keys got %v", ks)
+	}
+	if !reflect.DeepEqual(vs, [][]string{{"a", "b"}, {"c", "d"}, {"e"}}) {
+		t.Fatalf("values got %v", vs)
+	}
+
+	ks, vs = nil, nil
+	for k, v := range ChunksReuse2(Enumerate(seqOf("a", "b", "c")), 2) {
+		ks = append(ks, slices.Clone(k))
+		vs = append(vs, slices.Clone(v))
+	}
+	if !reflect.DeepEqual(ks, [][]int{{0, 1}, {2}}) || !reflect.DeepEqual(vs, [][]string{{"a", "b"}, {"c"}}) {
+		t.Fatalf("reuse got %v %v", ks, vs)
+	}
+	stopEarly2(Chunks2(Enumerate(Range1(10)), 2))
+}
+
+func TestWindows2(t *testing.T) {
+	var ks, vs [][]int
+	for k, v := range Windows2(Enumerate(Range2(10, 14)), 3, 1) {
+		ks = append(ks, k)
+		vs = append(vs, v)
+	}
+	if !reflect.DeepEqual(ks, [][]int{{0, 1, 2}, {1, 2, 3}}) {
+		t.Fatalf("keys got %v", ks)
+	}
+	if !reflect.DeepEqual(vs, [][]int{{10, 11, 12}, {11, 12, 13}}) {
+		t.Fatalf("values got %v", vs)
+	}
+
+	ks = nil
+	for k := range WindowsReuse2(Enumerate(Range1(5)), 2, 2) {
+		ks = append(ks, slices.Clone(k))
+	}
+	if !reflect.DeepEqual(ks, [][]int{{0, 1}, {2, 3}}) {
+		t.Fatalf("reuse got %v", ks)
+	}
+	stopEarly2(Windows2(Enumerate(Range1(10)), 2, 1))
+}
+
+func TestChunkBy2(t *testing.T) {
+	sameKey := func(k1 string, _ int, k2 string, _ int) bool { return k1 == k2 }
+	src := seq2Of(
+		struct {
+			K string
+			V int
+		}{"a", 1},
+		struct {
+			K string
+			V int
+		}{"a", 2},
+		struct {
+			K string
+			V int
+		}{"b", 3},
+	)
+	var ks [][]string
+	var vs [][]int
+	for k, v := range ChunkBy2(src, sameKey) {
+		ks = append(ks, k)
+		vs = append(vs, v)
+	}
+	if !reflect.DeepEqual(ks, [][]string{{"a", "a"}, {"b"}}) || !reflect.DeepEqual(vs, [][]int{{1, 2}, {3}}) {
+		t.Fatalf("got %v %v", ks, vs)
+	}
+
+	vs = nil
+	for _, v := range ChunkByReuse2(src, sameKey) {
+		vs = append(vs, slices.Clone(v))
+	}
+	if !reflect.DeepEqual(vs, [][]int{{1, 2}, {3}}) {
+		t.Fatalf("reuse got %v", vs)
+	}
+	stopEarly2(ChunkBy2(src, sameKey))
+}
+
+func TestDistinct2(t *testing.T) {
+	type kv = struct {
+		K string
+		V int
+	}
+	src := seq2Of(kv{"a", 1}, kv{"b", 2}, kv{"a", 3}, kv{"c", 4}, kv{"b", 5})
+	var ks []string
+	var vs []int
+	for k, v := range Distinct2(src) {
+		ks = append(ks, k)
+		vs = append(vs, v)
+	}
+	if !reflect.DeepEqual(ks, []string{"a", "b", "c"}) || !reflect.DeepEqual(vs, []int{1, 2, 4}) {
+		t.Fatalf("got %v %v", ks, vs)
+	}
+
+	vs = nil
+	for _, v := range DistinctLRU2(src, 1) {
+		vs = append(vs, v)
+	}
+	if !reflect.DeepEqual(vs, []int{1, 2, 3, 4, 5}) {
+		t.Fatalf("DistinctLRU2 got %v", vs)
+	}
+	vs = nil
+	for _, v := range DistinctLRU2(src, 0) {
+		vs = append(vs, v)
+	}
+	if len(vs) != 5 {
+		t.Fatalf("DistinctLRU2 size 0 got %v", vs)
+	}
+	vs = nil
+	for _, v := range DistinctBy2(src, func(k string, v int) bool { return v%2 == 0 }) {
+		vs = append(vs, v)
+	}
+	if !reflect.DeepEqual(vs, []int{1, 2}) {
+		t.Fatalf("DistinctBy2 got %v", vs)
+	}
+	vs = nil
+	for _, v := range DistinctByLRU2(src, func(k string, v int) bool { return v%2 == 0 }, 1) {
+		vs = append(vs, v)
+	}
+	if !reflect.DeepEqual(vs, []int{1, 2, 3, 4, 5}) {
+		t.Fatalf("DistinctByLRU2 got %v", vs)
+	}
+	stopEarly2(Distinct2(src))
+	stopEarly2(DistinctLRU2(src, 2))
+	stopEarly2(DistinctLRU2(src, 0))
+	stopEarly2(DistinctByLRU2(src, func(k string, _ int) string { return k }, 2))
+}
+
+func TestDedup2(t *testing.T) {
+	type kv = struct {
+		K string
+		V int
+	}
+	src := seq2Of(kv{"a", 1}, kv{"a", 2}, kv{"b", 3}, kv{"a", 4})
+	var vs []int
+	for _, v := range Dedup2(src) {
+		vs = append(vs, v)
+	}
+	if !reflect.DeepEqual(vs, []int{1, 3, 4}) {
+		t.Fatalf("got %v, want [1 3 4]", vs)
+	}
+	vs = nil
+	for _, v := range DedupFunc2(src, func(_ string, v1 int, _ string, v2 int) bool { return v2-v1 <= 1 }) {
+		vs = append(vs, v)
+	}
+	if !reflect.DeepEqual(vs, []int{1, 3}) {
+		t.Fatalf("got %v, want [1 3]", vs)
+	}
+	stopEarly2(Dedup2(src))
+}
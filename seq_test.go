@@ -4,6 +4,7 @@ import (
 	"errors"
 	"iter"
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -241,6 +242,84 @@ func TestRepeat(t *testing.T) {
 	}
 }
 
+func TestCycle(t *testing.T) {
+	got := ToSlice(Take(Cycle(seqOf(1, 2, 3)), 7))
+	if want := []int{1, 2, 3, 1, 2, 3, 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := ToSlice(Cycle(Empty[int]())); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	stopEarly(Cycle(seqOf(1)))
+}
+
+func TestCycleN(t *testing.T) {
+	got := ToSlice(CycleN(seqOf(1, 2), 3))
+	if want := []int{1, 2, 1, 2, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for _, n := range []int{0, -1} {
+		if got := ToSlice(CycleN(seqOf(1, 2), n)); len(got) != 0 {
+			t.Fatalf("n=%d: got %v, want empty", n, got)
+		}
+	}
+	passes := 0
+	empty := func(yield func(int) bool) { passes++ }
+	if got := ToSlice(CycleN(empty, 5)); len(got) != 0 || passes != 1 {
+		t.Fatalf("got %v after %d passes, want empty after 1", got, passes)
+	}
+	stopEarly(CycleN(seqOf(1), 2))
+}
+
+func TestCycleBuffered(t *testing.T) {
+	passes := 0
+	src := func(yield func(int) bool) {
+		passes++
+		for _, v := range []int{1, 2, 3} {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+	got := ToSlice(Take(CycleBuffered(src), 8))
+	if want := []int{1, 2, 3, 1, 2, 3, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if passes != 1 {
+		t.Fatalf("source iterated %d times, want 1", passes)
+	}
+	if got := ToSlice(CycleBuffered(Empty[int]())); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	stopEarly(CycleBuffered(seqOf(1)))
+
+	// Breaking out of the first pass releases the source.
+	once, released := onceSource(t, 1, 2, 3)
+	if got := ToSlice(Take(CycleBuffered(once), 2)); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("got %v, want [1 2]", got)
+	}
+	if !*released {
+		t.Fatal("source not released after Take stopped the first pass")
+	}
+	once, released = onceSource(t, 1, 2)
+	if got := ToSlice(Take(CycleBuffered(once), 3)); !reflect.DeepEqual(got, []int{1, 2, 1}) {
+		t.Fatalf("got %v, want [1 2 1]", got)
+	}
+	if !*released {
+		t.Fatal("source not released after the first pass")
+	}
+
+	// A single-use source is replayed by every range over the result.
+	f, _ := counter(2)
+	cb := CycleBuffered(FromFunc(f))
+	for pass := range 2 {
+		got := ToSlice(Take(cb, 5))
+		if want := []int{1, 2, 1, 2, 1}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("pass %d: got %v, want %v", pass, got, want)
+		}
+	}
+}
+
 // ============================================================================
 // Transform
 // ============================================================================
@@ -1158,6 +1237,82 @@ func TestChain(t *testing.T) {
 	}
 }
 
+func TestConcat(t *testing.T) {
+	got := ToSlice(Concat(Range1(2), Empty[int](), Once(5), Range2(10, 12)))
+	if want := []int{0, 1, 5, 10, 11}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := ToSlice(Concat[int]()); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	consumed := false
+	second := func(yield func(int) bool) { consumed = true }
+	stopEarly(Concat(seqOf(1), second))
+	if consumed {
+		t.Fatal("Concat consumed a source after the consumer stopped")
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	got := ToSlice(Interleave(seqOf(1, 2, 3), seqOf(10), Empty[int](), seqOf(20, 21)))
+	if want := []int{1, 10, 20, 2, 21, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := ToSlice(Interleave[int]()); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	got = ToSlice(Take(Interleave(Repeat(0), seqOf(1, 2)), 6))
+	if want := []int{0, 1, 0, 2, 0, 0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	stopEarly(Interleave(seqOf(1), seqOf(2)))
+}
+
+func TestInterleaveShortest(t *testing.T) {
+	got := ToSlice(InterleaveShortest(seqOf(1, 2, 3), seqOf(10), seqOf(20, 21)))
+	if want := []int{1, 10, 20}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	got = ToSlice(InterleaveShortest(seqOf(1, 2, 3), seqOf(10, 11, 12, 13)))
+	if want := []int{1, 10, 2, 11, 3, 12}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := ToSlice(InterleaveShortest[int]()); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	got = ToSlice(InterleaveShortest(Repeat(0), seqOf(1, 2)))
+	if want := []int{0, 1, 0, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	stopEarly(InterleaveShortest(seqOf(1), seqOf(2)))
+}
+
+func TestIntersperse(t *testing.T) {
+	got := ToSlice(Intersperse(seqOf("a", "b", "c"), ","))
+	if want := []string{"a", ",", "b", ",", "c"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := ToSlice(Intersperse(seqOf("a"), ",")); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Fatalf("got %v, want [a]", got)
+	}
+	if got := ToSlice(Intersperse(Empty[string](), ",")); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	stopEarly(Intersperse(seqOf(1, 2), 0))
+}
+
+func TestIntersperseWith(t *testing.T) {
+	n := 0
+	got := ToSlice(IntersperseWith(seqOf(0, 0, 0), func() int { n++; return n }))
+	if want := []int{0, 1, 0, 2, 0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if n != 2 {
+		t.Fatalf("separator built %d times, want 2", n)
+	}
+	stopEarly(IntersperseWith(seqOf(1, 2), func() int { return 0 }))
+}
+
 func TestForEach(t *testing.T) {
 	sum := 0
 	ForEach(Range1(5), func(v int) { sum += v })
@@ -1481,3 +1636,168 @@ func TestGeneratorsEarlyStop(t *testing.T) {
 	stopEarly2(Cast[int](func(yield func(any) bool) { yield(1) }))
 	stopEarly2(Zip(Range1(10), Range1(10)))
 }
+
+func TestChunks(t *testing.T) {
+	got := ToSlice(Chunks(Range1(7), 3))
+	want := [][]int{{0, 1, 2}, {3, 4, 5}, {6}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := ToSlice(Chunks(Range1(6), 3)); !reflect.DeepEqual(got, [][]int{{0, 1, 2}, {3, 4, 5}}) {
+		t.Fatalf("got %v", got)
+	}
+	if got := ToSlice(Chunks(Range1(5), 0)); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	if got := ToSlice(Chunks(Empty[int](), 2)); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	// Retained chunks must not be overwritten by later ones.
+	got = ToSlice(Chunks(Range1(4), 2))
+	got[0][0] = 100
+	if !reflect.DeepEqual(got, [][]int{{100, 1}, {2, 3}}) {
+		t.Fatalf("got %v", got)
+	}
+	stopEarly(Chunks(Range1(10), 2))
+}
+
+func TestChunksReuse(t *testing.T) {
+	var got [][]int
+	var first *int
+	for c := range ChunksReuse(Range1(7), 3) {
+		if first == nil {
+			first = &c[0]
+		} else if &c[0] != first {
+			t.Fatal("ChunksReuse allocated a new buffer")
+		}
+		got = append(got, slices.Clone(c))
+	}
+	want := [][]int{{0, 1, 2}, {3, 4, 5}, {6}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	stopEarly(ChunksReuse(Range1(10), 2))
+}
+
+func TestWindows(t *testing.T) {
+	cases := []struct {
+		n, size, step int
+		want          [][]int
+	}{
+		{5, 3, 1, [][]int{{0, 1, 2}, {1, 2, 3}, {2, 3, 4}}},
+		{6, 2, 2, [][]int{{0, 1}, {2, 3}, {4, 5}}},
+		{7, 2, 3, [][]int{{0, 1}, {3, 4}}},
+		{6, 4, 2, [][]int{{0, 1, 2, 3}, {2, 3, 4, 5}}},
+		{2, 3, 1, nil},
+		{5, 0, 1, nil},
+		{5, 2, 0, nil},
+	}
+	for _, c := range cases {
+		got := ToSlice(Windows(Range1(c.n), c.size, c.step))
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("Windows(Range1(%d), %d, %d) = %v, want %v", c.n, c.size, c.step, got, c.want)
+		}
+		var reused [][]int
+		for w := range WindowsReuse(Range1(c.n), c.size, c.step) {
+			reused = append(reused, slices.Clone(w))
+		}
+		if !reflect.DeepEqual(reused, c.want) {
+			t.Errorf("WindowsReuse(Range1(%d), %d, %d) = %v, want %v", c.n, c.size, c.step, reused, c.want)
+		}
+	}
+	stopEarly(Windows(Range1(10), 2, 1))
+	stopEarly(WindowsReuse(Range1(10), 2, 1))
+}
+
+func TestChunkBy(t *testing.T) {
+	same := func(prev, cur int) bool { return prev == cur }
+	got := ToSlice(ChunkBy(seqOf(1, 1, 2, 3, 3, 3, 1), same))
+	want := [][]int{{1, 1}, {2}, {3, 3, 3}, {1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	ascending := func(prev, cur int) bool { return prev < cur }
+	got = ToSlice(ChunkBy(seqOf(1, 2, 5, 3, 4, 0), ascending))
+	want = [][]int{{1, 2, 5}, {3, 4}, {0}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := ToSlice(ChunkBy(Empty[int](), same)); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+
+	var reused [][]int
+	for c := range ChunkByReuse(seqOf(1, 1, 2, 3, 3, 3, 1), same) {
+		reused = append(reused, slices.Clone(c))
+	}
+	if !reflect.DeepEqual(reused, [][]int{{1, 1}, {2}, {3, 3, 3}, {1}}) {
+		t.Fatalf("reuse got %v", reused)
+	}
+	stopEarly(ChunkBy(seqOf(1, 2), same))
+	stopEarly(ChunkByReuse(seqOf(1, 2), same))
+}
+
+func TestDistinct(t *testing.T) {
+	got := ToSlice(Distinct(seqOf(3, 1, 3, 2, 1, 4)))
+	if !reflect.DeepEqual(got, []int{3, 1, 2, 4}) {
+		t.Fatalf("got %v, want [3 1 2 4]", got)
+	}
+	if got := ToSlice(Distinct(Empty[int]())); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	// Lazy over an infinite source.
+	cycle := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if !yield(i % 3) {
+				return
+			}
+		}
+	}
+	if got := ToSlice(Take(Distinct(cycle), 3)); !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Fatalf("got %v, want [0 1 2]", got)
+	}
+	stopEarly(Distinct(seqOf(1, 2)))
+}
+
+func TestDistinctBy(t *testing.T) {
+	got := ToSlice(DistinctBy(seqOf("apple", "avocado", "banana", "blueberry", "cherry"), func(s string) byte { return s[0] }))
+	want := []string{"apple", "banana", "cherry"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestDistinctLRU(t *testing.T) {
+	got := ToSlice(DistinctLRU(seqOf(1, 2, 1, 3, 1, 2), 2))
+	if !reflect.DeepEqual(got, []int{1, 2, 3, 2}) {
+		t.Fatalf("got %v, want [1 2 3 2]", got)
+	}
+	if got := ToSlice(DistinctLRU(seqOf(1, 1, 2), 0)); !reflect.DeepEqual(got, []int{1, 1, 2}) {
+		t.Fatalf("size 0: got %v, want [1 1 2]", got)
+	}
+	got = ToSlice(DistinctByLRU(seqOf(10, 11, 20, 12, 30), func(x int) int { return x / 10 }, 1))
+	if !reflect.DeepEqual(got, []int{10, 20, 12, 30}) {
+		t.Fatalf("got %v, want [10 20 12 30]", got)
+	}
+	stopEarly(DistinctLRU(seqOf(1, 2), 2))
+	stopEarly(DistinctLRU(seqOf(1, 2), 0))
+}
+
+func TestDedup(t *testing.T) {
+	got := ToSlice(Dedup(seqOf(1, 1, 2, 2, 2, 1, 3, 3)))
+	if !reflect.DeepEqual(got, []int{1, 2, 1, 3}) {
+		t.Fatalf("got %v, want [1 2 1 3]", got)
+	}
+	if got := ToSlice(Dedup(Empty[int]())); len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+	// The zero value must not be mistaken for a previous element.
+	if got := ToSlice(Dedup(seqOf(0, 0, 1))); !reflect.DeepEqual(got, []int{0, 1}) {
+		t.Fatalf("got %v, want [0 1]", got)
+	}
+	closeEnough := func(a, b float64) bool { return b-a < 0.5 && a-b < 0.5 }
+	if got := ToSlice(DedupFunc(seqOf(1.0, 1.2, 1.4, 3.0), closeEnough)); !reflect.DeepEqual(got, []float64{1.0, 3.0}) {
+		t.Fatalf("got %v, want [1 3]", got)
+	}
+	stopEarly(Dedup(seqOf(1, 2)))
+}
@@ -0,0 +1,130 @@
+package xiter
+
+import (
+	"errors"
+	"iter"
+)
+
+// ============================================================================
+// Error-carrying sequences
+// ============================================================================
+//
+// The functions below operate on iter.Seq2[E, error], the shape used for
+// sources whose elements may individually fail, such as decoded records or
+// database rows. A pair with a nil error carries a value; a pair with a
+// non-nil error carries a failure and its value is not meaningful.
+
+// MapErr applies f to the value of every successful pair and yields f's
+// result. Failed pairs are passed through unchanged with the zero value of
+// E2, and an error returned by f is yielded in place of the value.
+//
+//	MapErr(rows, func(r Row) (User, error) { return decodeUser(r) })
+func MapErr[E1, E2 any](s iter.Seq2[E1, error], f func(E1) (E2, error)) iter.Seq2[E2, error] {
+	return func(yield func(E2, error) bool) {
+		for e, err := range s {
+			if err != nil {
+				var zero E2
+				if !yield(zero, err) {
+					return
+				}
+				continue
+			}
+			if !yield(f(e)) {
+				return
+			}
+		}
+	}
+}
+
+// FilterErr yields the successful pairs whose value satisfies f, and every
+// failed pair unchanged. f is never called for a failed pair.
+func FilterErr[E any](s iter.Seq2[E, error], f func(E) bool) iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		for e, err := range s {
+			if (err != nil || f(e)) && !yield(e, err) {
+				return
+			}
+		}
+	}
+}
+
+// FlatMapErr applies f to the value of every successful pair and flattens the
+// resulting error-carrying sequences into one. Failed pairs are passed
+// through unchanged with the zero value of E2.
+func FlatMapErr[E1, E2 any](s iter.Seq2[E1, error], f func(E1) iter.Seq2[E2, error]) iter.Seq2[E2, error] {
+	return func(yield func(E2, error) bool) {
+		for e1, err := range s {
+			if err != nil {
+				var zero E2
+				if !yield(zero, err) {
+					return
+				}
+				continue
+			}
+			for e2, err := range f(e1) {
+				if !yield(e2, err) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// CollectErr gathers the values of s into a slice, stopping at the first
+// failed pair. It returns the values collected before the failure together
+// with its error, or every value and nil when no pair failed. An empty input
+// yields a nil slice.
+func CollectErr[E any](s iter.Seq2[E, error]) ([]E, error) {
+	var out []E
+	for e, err := range s {
+		if err != nil {
+			return out, err
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// CollectAllErr consumes the whole of s, gathering the values of successful
+// pairs into a slice and combining every error with errors.Join. The returned
+// error is nil when no pair failed.
+func CollectAllErr[E any](s iter.Seq2[E, error]) ([]E, error) {
+	var out []E
+	var errs []error
+	for e, err := range s {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		out = append(out, e)
+	}
+	return out, errors.Join(errs...)
+}
+
+// SkipErrors yields the values of successful pairs and silently drops failed
+// ones.
+func SkipErrors[E any](s iter.Seq2[E, error]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for e, err := range s {
+			if err == nil && !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Must yields the values of s and panics with the error of the first failed
+// pair. Use it where a failure indicates a programming error, such as
+// decoding embedded, known-good data.
+func Must[E any](s iter.Seq2[E, error]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for e, err := range s {
+			if err != nil {
+				panic(err)
+			}
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
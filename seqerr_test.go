@@ -0,0 +1,145 @@
+package xiter
+
+import (
+	"errors"
+	"iter"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// resultsOf builds an error-carrying sequence from the given value/error
+// pairs, honoring yield's stop signal.
+func resultsOf[E any](pairs ...pair[E, error]) iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		for _, p := range pairs {
+			if !yield(p.k, p.v) {
+				return
+			}
+		}
+	}
+}
+
+// success and failure build the pairs consumed by resultsOf.
+func success[E any](e E) pair[E, error]       { return pair[E, error]{k: e} }
+func failure[E any](err error) pair[E, error] { return pair[E, error]{v: err} }
+
+var (
+	errA = errors.New("a")
+	errB = errors.New("b")
+)
+
+func TestMapErr(t *testing.T) {
+	src := resultsOf(success("1"), failure[string](errA), success("x"), success("3"))
+	var vals []int
+	var errs []error
+	for v, err := range MapErr(src, strconv.Atoi) {
+		vals = append(vals, v)
+		errs = append(errs, err)
+	}
+	if !reflect.DeepEqual(vals, []int{1, 0, 0, 3}) {
+		t.Fatalf("vals got %v", vals)
+	}
+	if errs[0] != nil || !errors.Is(errs[1], errA) || errs[2] == nil || errs[3] != nil {
+		t.Fatalf("errs got %v", errs)
+	}
+	stopEarly2(MapErr(resultsOf(success("1")), strconv.Atoi))
+	stopEarly2(MapErr(resultsOf(failure[string](errA)), strconv.Atoi))
+}
+
+func TestFilterErr(t *testing.T) {
+	src := resultsOf(success(1), success(2), failure[int](errA), success(4))
+	called := 0
+	got, err := CollectAllErr(FilterErr(src, func(v int) bool {
+		called++
+		return v%2 == 0
+	}))
+	if !reflect.DeepEqual(got, []int{2, 4}) {
+		t.Fatalf("got %v, want [2 4]", got)
+	}
+	if !errors.Is(err, errA) {
+		t.Fatalf("got error %v, want %v", err, errA)
+	}
+	if called != 3 {
+		t.Fatalf("predicate called %d times, want 3", called)
+	}
+	stopEarly2(FilterErr(resultsOf(success(1)), func(int) bool { return true }))
+}
+
+func TestFlatMapErr(t *testing.T) {
+	src := resultsOf(success(2), failure[int](errA), success(1))
+	var vals []int
+	var errs []error
+	for v, err := range FlatMapErr(src, func(n int) iter.Seq2[int, error] {
+		return resultsOf(success(n*10), failure[int](errB))
+	}) {
+		vals = append(vals, v)
+		errs = append(errs, err)
+	}
+	if !reflect.DeepEqual(vals, []int{20, 0, 0, 10, 0}) {
+		t.Fatalf("vals got %v", vals)
+	}
+	want := []error{nil, errB, errA, nil, errB}
+	if !reflect.DeepEqual(errs, want) {
+		t.Fatalf("errs got %v, want %v", errs, want)
+	}
+	stopEarly2(FlatMapErr(resultsOf(success(1)), func(n int) iter.Seq2[int, error] { return resultsOf(success(n)) }))
+	stopEarly2(FlatMapErr(resultsOf(failure[int](errA)), func(n int) iter.Seq2[int, error] { return resultsOf(success(n)) }))
+}
+
+func TestCollectErr(t *testing.T) {
+	pulled := 0
+	src := Inspect2(resultsOf(success(1), success(2), failure[int](errA), success(4)), func(int, error) { pulled++ })
+	got, err := CollectErr(src)
+	if !reflect.DeepEqual(got, []int{1, 2}) || !errors.Is(err, errA) {
+		t.Fatalf("got (%v, %v), want ([1 2], %v)", got, err, errA)
+	}
+	if pulled != 3 {
+		t.Fatalf("pulled %d pairs, want 3", pulled)
+	}
+
+	got, err = CollectErr(resultsOf(success(1), success(2)))
+	if !reflect.DeepEqual(got, []int{1, 2}) || err != nil {
+		t.Fatalf("got (%v, %v), want ([1 2], nil)", got, err)
+	}
+	if got, err := CollectErr(resultsOf[int]()); got != nil || err != nil {
+		t.Fatalf("got (%v, %v), want (nil, nil)", got, err)
+	}
+}
+
+func TestCollectAllErr(t *testing.T) {
+	got, err := CollectAllErr(resultsOf(success(1), failure[int](errA), success(3), failure[int](errB)))
+	if !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Fatalf("got %v, want [1 3]", got)
+	}
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Fatalf("got error %v, want both %v and %v", err, errA, errB)
+	}
+	if _, err := CollectAllErr(resultsOf(success(1))); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+}
+
+func TestSkipErrors(t *testing.T) {
+	got := ToSlice(SkipErrors(resultsOf(success(1), failure[int](errA), success(3))))
+	if !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Fatalf("got %v, want [1 3]", got)
+	}
+	stopEarly(SkipErrors(resultsOf(success(1))))
+}
+
+func TestMust(t *testing.T) {
+	got := ToSlice(Must(resultsOf(success(1), success(2))))
+	if !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("got %v, want [1 2]", got)
+	}
+	stopEarly(Must(resultsOf(success(1))))
+
+	defer func() {
+		if r := recover(); r != errA {
+			t.Fatalf("recovered %v, want %v", r, errA)
+		}
+	}()
+	ToSlice(Must(resultsOf(success(1), failure[int](errA))))
+	t.Fatal("expected panic")
+}
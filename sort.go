@@ -0,0 +1,245 @@
+package xiter
+
+import (
+	"bufio"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"slices"
+)
+
+// ============================================================================
+// Sorting
+// ============================================================================
+
+// Sorted returns a sequence over the elements of s in ascending order
+// according to cmp.Compare. Each iteration collects all of s into memory and
+// sorts it before yielding the first element; for sequences larger than
+// memory, use SortedExternal.
+//
+//	Sorted(seqOf(3, 1, 2))  // yields 1, 2, 3
+func Sorted[E cmp.Ordered](s iter.Seq[E]) iter.Seq[E] {
+	return sorted(s, slices.Sort[[]E])
+}
+
+// SortedFunc is like Sorted but orders elements with cmp, which must follow
+// the cmp.Compare convention. The order of equal elements is unspecified.
+func SortedFunc[E any](s iter.Seq[E], cmp func(E, E) int) iter.Seq[E] {
+	return sorted(s, func(buf []E) { slices.SortFunc(buf, cmp) })
+}
+
+// SortedStableFunc is like SortedFunc but keeps equal elements in their
+// original order.
+//
+//	byLen := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+//	SortedStableFunc(seqOf("bb", "a", "cc", "d"), byLen)  // yields "a", "d", "bb", "cc"
+func SortedStableFunc[E any](s iter.Seq[E], cmp func(E, E) int) iter.Seq[E] {
+	return sorted(s, func(buf []E) { slices.SortStableFunc(buf, cmp) })
+}
+
+// SortedBy returns a sequence over the elements of s in ascending order of
+// key, calling key once per element rather than once per comparison. Equal
+// keys keep their original order.
+//
+//	SortedBy(seqOf("ccc", "a", "bb"), func(s string) int { return len(s) })
+//	// yields "a", "bb", "ccc"
+func SortedBy[E any, K cmp.Ordered](s iter.Seq[E], key func(E) K) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		keyed := slices.Collect(Map(s, func(e E) pair[K, E] { return pair[K, E]{key(e), e} }))
+		slices.SortStableFunc(keyed, func(a, b pair[K, E]) int { return cmp.Compare(a.k, b.k) })
+		for _, kv := range keyed {
+			if !yield(kv.v) {
+				return
+			}
+		}
+	}
+}
+
+// sorted collects s on every iteration, sorts it with sortFn and yields the
+// result.
+func sorted[E any](s iter.Seq[E], sortFn func([]E)) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		buf := slices.Collect(s)
+		sortFn(buf)
+		for _, e := range buf {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Codec encodes elements to and decodes them from the temporary files of
+// SortedExternal. Decode reads one element written by Encode and returns
+// io.EOF, and only io.EOF, when the input ends cleanly before an element. The
+// writer and reader passed in are buffered; the reader also implements
+// io.ByteReader, as needed by e.g. binary.ReadVarint.
+type Codec[E any] interface {
+	Encode(w io.Writer, e E) error
+	Decode(r io.Reader) (E, error)
+}
+
+// SpillOption configures SortedExternal.
+type SpillOption func(*spillConfig)
+
+type spillConfig struct {
+	dir     string
+	runSize int
+}
+
+// DefaultRunSize is the number of elements SortedExternal sorts in memory at
+// a time unless configured otherwise with RunSize.
+const DefaultRunSize = 1 << 16
+
+// SpillDir sets the directory in which SortedExternal creates its temporary
+// files. The default, also used for "", is os.TempDir().
+func SpillDir(dir string) SpillOption {
+	return func(c *spillConfig) { c.dir = dir }
+}
+
+// RunSize sets how many elements SortedExternal holds and sorts in memory
+// before spilling them to a file as one sorted run. When n <= 0,
+// DefaultRunSize is used.
+func RunSize(n int) SpillOption {
+	return func(c *spillConfig) {
+		if n <= 0 {
+			n = DefaultRunSize
+		}
+		c.runSize = n
+	}
+}
+
+// SortedExternal sorts sequences too large for memory. It reads s in runs of
+// RunSize elements; whenever a run is full and more elements follow, the run
+// is sorted stably with cmp and written to a temporary file with codec. When
+// s is exhausted, the runs are merged lazily as the result is iterated,
+// holding one element per spilled run in memory besides the last run. A
+// sequence that fits in a single run is sorted in memory without touching
+// the disk. Equal elements keep their original order.
+//
+// Every iteration sorts s afresh. The temporary files live in a directory
+// created under SpillDir and are removed when iteration ends, including when
+// the consumer breaks early. Every spilled run stays open during the merge,
+// so choose a RunSize that keeps the number of runs well below the process's
+// open-file limit. An error creating, writing or reading the files, or from
+// codec, is yielded as the final (zero, err) pair; elements already yielded
+// are then only a sorted prefix of the result.
+//
+//	sorted := SortedExternal(records, byTimestamp, recordCodec{}, RunSize(1_000_000), SpillDir("/scratch"))
+//	for r, err := range sorted { ... }
+func SortedExternal[E any](s iter.Seq[E], cmp func(E, E) int, codec Codec[E], opts ...SpillOption) iter.Seq2[E, error] {
+	cfg := spillConfig{runSize: DefaultRunSize}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return func(yield func(E, error) bool) {
+		var zero E
+		sp := spiller[E]{cfg: cfg, codec: codec}
+		defer sp.cleanup()
+
+		var buf []E
+		for e := range s {
+			if len(buf) == cfg.runSize {
+				slices.SortStableFunc(buf, cmp)
+				if err := sp.spill(buf); err != nil {
+					yield(zero, err)
+					return
+				}
+				clear(buf)
+				buf = buf[:0]
+			}
+			buf = append(buf, e)
+		}
+		slices.SortStableFunc(buf, cmp)
+
+		runs := make([]iter.Seq[E], 0, len(sp.files)+1)
+		for _, f := range sp.files {
+			runs = append(runs, sp.read(f))
+		}
+		runs = append(runs, slices.Values(buf))
+		for e := range MergeSortedFunc(cmp, runs...) {
+			if sp.err != nil {
+				break
+			}
+			if !yield(e, nil) {
+				return
+			}
+		}
+		if sp.err != nil {
+			yield(zero, sp.err)
+		}
+	}
+}
+
+// spiller writes sorted runs to temporary files and reads them back.
+type spiller[E any] struct {
+	cfg   spillConfig
+	codec Codec[E]
+	dir   string
+	files []*os.File
+	// err is the first error reading a run back.
+	err error
+}
+
+// spill writes run to a new temporary file and rewinds it for reading.
+func (sp *spiller[E]) spill(run []E) error {
+	if sp.dir == "" {
+		dir, err := os.MkdirTemp(sp.cfg.dir, "xiter-sort-")
+		if err != nil {
+			return err
+		}
+		sp.dir = dir
+	}
+	f, err := os.CreateTemp(sp.dir, "run-")
+	if err != nil {
+		return err
+	}
+	sp.files = append(sp.files, f)
+	w := bufio.NewWriter(f)
+	for _, e := range run {
+		if err := sp.codec.Encode(w, e); err != nil {
+			return fmt.Errorf("xiter: encoding sort run: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	_, err = f.Seek(0, io.SeekStart)
+	return err
+}
+
+// read returns a sequence over the run stored in f. A decoding error ends the
+// sequence and is recorded in sp.err.
+func (sp *spiller[E]) read(f *os.File) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		r := bufio.NewReader(f)
+		for {
+			e, err := sp.codec.Decode(r)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				if sp.err == nil {
+					sp.err = fmt.Errorf("xiter: decoding sort run: %w", err)
+				}
+				return
+			}
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// cleanup closes and removes every temporary file.
+func (sp *spiller[E]) cleanup() {
+	for _, f := range sp.files {
+		f.Close()
+	}
+	if sp.dir != "" {
+		os.RemoveAll(sp.dir)
+	}
+}
@@ -0,0 +1,190 @@
+package xiter
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSorted(t *testing.T) {
+	s := seqOf(3, 1, 4, 1, 5, 9, 2, 6)
+	if got := ToSlice(Sorted(s)); !reflect.DeepEqual(got, []int{1, 1, 2, 3, 4, 5, 6, 9}) {
+		t.Fatalf("Sorted got %v", got)
+	}
+	desc := func(a, b int) int { return cmp.Compare(b, a) }
+	if got := ToSlice(SortedFunc(s, desc)); !reflect.DeepEqual(got, []int{9, 6, 5, 4, 3, 2, 1, 1}) {
+		t.Fatalf("SortedFunc got %v", got)
+	}
+	byLen := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+	if got := ToSlice(SortedStableFunc(seqOf("bb", "a", "cc", "d", "e"), byLen)); !reflect.DeepEqual(got, []string{"a", "d", "e", "bb", "cc"}) {
+		t.Fatalf("SortedStableFunc got %v", got)
+	}
+	if got := ToSlice(Sorted(Empty[int]())); got != nil {
+		t.Fatalf("empty got %v", got)
+	}
+	stopEarly(Sorted(s))
+}
+
+func TestSortedBy(t *testing.T) {
+	calls := 0
+	length := func(s string) int {
+		calls++
+		return len(s)
+	}
+	got := ToSlice(SortedBy(seqOf("ccc", "a", "bb", "z", "yy"), length))
+	if !reflect.DeepEqual(got, []string{"a", "z", "bb", "yy", "ccc"}) {
+		t.Fatalf("got %v", got)
+	}
+	if calls != 5 {
+		t.Fatalf("key called %d times, want 5", calls)
+	}
+	stopEarly(SortedBy(seqOf("b", "a"), length))
+}
+
+// record is sorted by key; seq records the original position to check
+// stability.
+type record struct{ key, seq int64 }
+
+// recordCodec stores a record as two varints.
+type recordCodec struct{}
+
+func (recordCodec) Encode(w io.Writer, r record) error {
+	_, err := w.Write(binary.AppendVarint(binary.AppendVarint(nil, r.key), r.seq))
+	return err
+}
+
+func (recordCodec) Decode(r io.Reader) (record, error) {
+	br := r.(io.ByteReader)
+	key, err := binary.ReadVarint(br)
+	if err != nil {
+		return record{}, err
+	}
+	seq, err := binary.ReadVarint(br)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return record{key, seq}, err
+}
+
+func byRecordKey(a, b record) int { return cmp.Compare(a.key, b.key) }
+
+func randomRecords(n int) []record {
+	r := rand.New(rand.NewPCG(12, 13))
+	recs := make([]record, n)
+	for i := range recs {
+		recs[i] = record{r.Int64N(100), int64(i)}
+	}
+	return recs
+}
+
+// spillDirEmpty fails the test if dir contains anything.
+func spillDirEmpty(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("spill directory not cleaned up: %v", entries)
+	}
+}
+
+func TestSortedExternal(t *testing.T) {
+	recs := randomRecords(10_000)
+	want := slices.Clone(recs)
+	slices.SortStableFunc(want, byRecordKey)
+
+	for _, runSize := range []int{0, 100, 999, 10_000, 20_000} {
+		dir := t.TempDir()
+		got, err := CollectErr(SortedExternal(slices.Values(recs), byRecordKey, recordCodec{}, RunSize(runSize), SpillDir(dir)))
+		if err != nil {
+			t.Fatalf("run size %d: %v", runSize, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("run size %d: result is not the stable sort of the input", runSize)
+		}
+		spillDirEmpty(t, dir)
+	}
+	if got, err := CollectErr(SortedExternal(Empty[record](), byRecordKey, recordCodec{})); got != nil || err != nil {
+		t.Fatalf("empty got %v, %v", got, err)
+	}
+}
+
+func TestSortedExternalEarlyBreak(t *testing.T) {
+	dir := t.TempDir()
+	s := SortedExternal(slices.Values(randomRecords(1000)), byRecordKey, recordCodec{}, RunSize(100), SpillDir(dir))
+	for _, err := range s {
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries, _ := os.ReadDir(dir)
+		if len(entries) != 1 {
+			t.Fatalf("want one temporary directory while sorting, got %v", entries)
+		}
+		runs, _ := os.ReadDir(filepath.Join(dir, entries[0].Name()))
+		if len(runs) != 9 {
+			t.Fatalf("want 9 spilled runs, got %d", len(runs))
+		}
+		break
+	}
+	spillDirEmpty(t, dir)
+}
+
+// failingCodec fails to encode after n elements, or to decode when decode is
+// set.
+type failingCodec struct {
+	recordCodec
+	n      *int
+	decode bool
+}
+
+var errCodec = errors.New("codec failure")
+
+func (c failingCodec) Encode(w io.Writer, r record) error {
+	if *c.n--; *c.n < 0 && !c.decode {
+		return errCodec
+	}
+	return c.recordCodec.Encode(w, r)
+}
+
+func (c failingCodec) Decode(r io.Reader) (record, error) {
+	if c.decode {
+		return record{}, errCodec
+	}
+	return c.recordCodec.Decode(r)
+}
+
+func TestSortedExternalErrors(t *testing.T) {
+	for _, decode := range []bool{false, true} {
+		dir := t.TempDir()
+		n := 150
+		codec := failingCodec{n: &n, decode: decode}
+		var last error
+		count := 0
+		for _, err := range SortedExternal(slices.Values(randomRecords(1000)), byRecordKey, codec, RunSize(100), SpillDir(dir)) {
+			last = err
+			count++
+		}
+		if !errors.Is(last, errCodec) {
+			t.Fatalf("decode=%v: last error got %v, want %v", decode, last, errCodec)
+		}
+		if count > 1000 {
+			t.Fatalf("decode=%v: yielded %d pairs", decode, count)
+		}
+		spillDirEmpty(t, dir)
+	}
+
+	// An unusable spill directory is reported, not ignored.
+	missing := filepath.Join(t.TempDir(), "missing")
+	_, err := CollectErr(SortedExternal(slices.Values(randomRecords(10)), byRecordKey, recordCodec{}, RunSize(5), SpillDir(missing)))
+	if err == nil {
+		t.Fatal("missing spill directory reported no error")
+	}
+}
@@ -0,0 +1,169 @@
+package xiter
+
+import (
+	"iter"
+	"math"
+)
+
+// ============================================================================
+// Statistics
+// ============================================================================
+
+// Sum returns the sum of the elements of s, or 0 when s is empty. Floats are
+// added with Neumaier's compensated summation, so the result stays accurate
+// over long sequences and mixed magnitudes; integers are added exactly and
+// wrap around on overflow, like the + operator.
+//
+//	Sum(seqOf(1, 2, 3))             // returns 6
+//	Sum(seqOf(1e100, 1.0, -1e100))  // returns 1, not 0
+func Sum[N Number](s iter.Seq[N]) N {
+	if !isFloat[N]() {
+		var sum N
+		for x := range s {
+			sum += x
+		}
+		return sum
+	}
+	var acc neumaier
+	for x := range s {
+		acc.add(float64(x))
+	}
+	return N(acc.sum())
+}
+
+// SumFunc returns the sum of f(e) over the elements of s, computed like Sum.
+//
+//	SumFunc(seqOf("a", "bc"), func(s string) int { return len(s) })  // returns 3
+func SumFunc[E any, N Number](s iter.Seq[E], f func(E) N) N {
+	return Sum(Map(s, f))
+}
+
+// Product returns the product of the elements of s, or 1 when s is empty.
+// Integers wrap around on overflow, like the * operator.
+//
+//	Product(seqOf(1, 2, 3, 4))  // returns 24
+func Product[N Number](s iter.Seq[N]) N {
+	prod := N(1)
+	for x := range s {
+		prod *= x
+	}
+	return prod
+}
+
+// Mean returns the arithmetic mean of the elements of s as a float64, or
+// (0, false) when s is empty. The total is accumulated with compensated
+// summation as in Sum.
+//
+//	Mean(seqOf(1, 2, 3, 4))  // returns (2.5, true)
+func Mean[N Number](s iter.Seq[N]) (float64, bool) {
+	var acc neumaier
+	n := 0
+	for x := range s {
+		acc.add(float64(x))
+		n++
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return acc.sum() / float64(n), true
+}
+
+// Variance returns the sample variance of the elements of s, i.e. the sum of
+// squared deviations from the mean divided by n-1. It returns (0, false)
+// when s has fewer than two elements. It is computed in a single pass with
+// Welford's algorithm, which does not suffer from the cancellation of the
+// naive sum-of-squares formula.
+//
+//	Variance(seqOf(2, 4, 4, 4, 5, 5, 7, 9))  // returns (4.571428..., true)
+func Variance[N Number](s iter.Seq[N]) (float64, bool) {
+	w := welfordOf(s)
+	if w.n < 2 {
+		return 0, false
+	}
+	return w.m2 / float64(w.n-1), true
+}
+
+// PopVariance returns the population variance of the elements of s, i.e.
+// the sum of squared deviations from the mean divided by n. It returns
+// (0, false) when s is empty.
+//
+//	PopVariance(seqOf(2, 4, 4, 4, 5, 5, 7, 9))  // returns (4, true)
+func PopVariance[N Number](s iter.Seq[N]) (float64, bool) {
+	w := welfordOf(s)
+	if w.n == 0 {
+		return 0, false
+	}
+	return w.m2 / float64(w.n), true
+}
+
+// StdDev returns the sample standard deviation of the elements of s, the
+// square root of Variance. It returns (0, false) when s has fewer than two
+// elements.
+func StdDev[N Number](s iter.Seq[N]) (float64, bool) {
+	v, ok := Variance(s)
+	return math.Sqrt(v), ok
+}
+
+// PopStdDev returns the population standard deviation of the elements of s,
+// the square root of PopVariance. It returns (0, false) when s is empty.
+//
+//	PopStdDev(seqOf(2, 4, 4, 4, 5, 5, 7, 9))  // returns (2, true)
+func PopStdDev[N Number](s iter.Seq[N]) (float64, bool) {
+	v, ok := PopVariance(s)
+	return math.Sqrt(v), ok
+}
+
+// isFloat reports whether N is a floating-point type.
+func isFloat[N Number]() bool {
+	return N(1)/2 != 0
+}
+
+// neumaier accumulates a float64 sum with Neumaier's variant of Kahan
+// summation, which also compensates when the addend is larger than the
+// running sum.
+type neumaier struct {
+	s, c float64
+}
+
+func (k *neumaier) add(x float64) {
+	t := k.s + x
+	if math.Abs(k.s) >= math.Abs(x) {
+		k.c += (k.s - t) + x
+	} else {
+		k.c += (x - t) + k.s
+	}
+	k.s = t
+}
+
+func (k *neumaier) sum() float64 {
+	// Once the sum is infinite or NaN the compensation is meaningless, and
+	// adding it would turn an infinity into NaN.
+	if math.IsInf(k.s, 0) || math.IsNaN(k.s) {
+		return k.s
+	}
+	return k.s + k.c
+}
+
+// welford tracks the count, mean and sum of squared deviations of a stream of
+// values using Welford's online algorithm.
+type welford struct {
+	n    int
+	mean float64
+	m2   float64
+}
+
+func (w *welford) add(x float64) {
+	w.n++
+	d := x - w.mean
+	w.mean += d / float64(w.n)
+	w.m2 += d * (x - w.mean)
+}
+
+// welfordOf feeds every element of s to a new welford.
+func welfordOf[N Number](s iter.Seq[N]) welford {
+	var w welford
+	for x := range s {
+		w.add(float64(x))
+	}
+	return w
+}
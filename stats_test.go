@@ -0,0 +1,92 @@
+package xiter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSum(t *testing.T) {
+	if got := Sum(seqOf(1, 2, 3)); got != 6 {
+		t.Fatalf("got %v, want 6", got)
+	}
+	if got := Sum(Empty[float64]()); got != 0 {
+		t.Fatalf("empty got %v, want 0", got)
+	}
+	if got := Sum(seqOf(1e100, 1.0, -1e100)); got != 1 {
+		t.Fatalf("got %v, want 1", got)
+	}
+	// 0.1 ten million times drifts visibly with naive summation.
+	tenths := Take(Repeat(0.1), 10_000_000)
+	if got := Sum(tenths); got != 1_000_000 {
+		t.Fatalf("got %v, want 1e6", got)
+	}
+	if got := Sum(seqOf(float32(0.5), 0.25)); got != 0.75 {
+		t.Fatalf("float32 got %v, want 0.75", got)
+	}
+	if got := Sum(seqOf(math.Inf(1), 1)); !math.IsInf(got, 1) {
+		t.Fatalf("got %v, want +Inf", got)
+	}
+	if got := Sum(seqOf[uint8](200, 100)); got != 44 {
+		t.Fatalf("uint8 got %v, want wrap-around 44", got)
+	}
+	type celsius float64
+	if got := Sum(seqOf[celsius](1.5, 2)); got != 3.5 {
+		t.Fatalf("named float got %v, want 3.5", got)
+	}
+}
+
+func TestSumFunc(t *testing.T) {
+	if got := SumFunc(seqOf("a", "bc", "def"), func(s string) int { return len(s) }); got != 6 {
+		t.Fatalf("got %v, want 6", got)
+	}
+}
+
+func TestProduct(t *testing.T) {
+	if got := Product(seqOf(1, 2, 3, 4)); got != 24 {
+		t.Fatalf("got %v, want 24", got)
+	}
+	if got := Product(Empty[float64]()); got != 1 {
+		t.Fatalf("empty got %v, want 1", got)
+	}
+}
+
+func TestMean(t *testing.T) {
+	if got, ok := Mean(seqOf(1, 2, 3, 4)); got != 2.5 || !ok {
+		t.Fatalf("got (%v, %v), want (2.5, true)", got, ok)
+	}
+	if _, ok := Mean(Empty[int]()); ok {
+		t.Fatal("empty reported a mean")
+	}
+}
+
+func TestVariance(t *testing.T) {
+	data := seqOf(2, 4, 4, 4, 5, 5, 7, 9)
+	approx := func(a, b float64) bool { return math.Abs(a-b) < 1e-12 }
+	if got, ok := Variance(data); !approx(got, 32.0/7) || !ok {
+		t.Fatalf("Variance got (%v, %v), want (%v, true)", got, ok, 32.0/7)
+	}
+	if got, ok := PopVariance(data); got != 4 || !ok {
+		t.Fatalf("PopVariance got (%v, %v), want (4, true)", got, ok)
+	}
+	if got, ok := StdDev(data); !approx(got, math.Sqrt(32.0/7)) || !ok {
+		t.Fatalf("StdDev got (%v, %v)", got, ok)
+	}
+	if got, ok := PopStdDev(data); got != 2 || !ok {
+		t.Fatalf("PopStdDev got (%v, %v), want (2, true)", got, ok)
+	}
+	if _, ok := Variance(seqOf(1)); ok {
+		t.Fatal("Variance of one element reported a result")
+	}
+	if got, ok := PopVariance(seqOf(1)); got != 0 || !ok {
+		t.Fatalf("PopVariance of one element got (%v, %v), want (0, true)", got, ok)
+	}
+	if _, ok := StdDev(Empty[int]()); ok {
+		t.Fatal("empty reported a standard deviation")
+	}
+
+	// A large offset wrecks the naive sum-of-squares formula but not Welford.
+	shifted := Map(data, func(x int) float64 { return 1e9 + float64(x) })
+	if got, _ := PopVariance(shifted); math.Abs(got-4) > 1e-6 {
+		t.Fatalf("shifted PopVariance got %v, want 4", got)
+	}
+}
@@ -2,8 +2,10 @@ package stream_test
 
 import (
 	"cmp"
+	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/go-board/xiter"
 	"github.com/go-board/xiter/stream"
@@ -100,6 +102,40 @@ func ExampleSeq_Inspect() {
 	// emit 1
 }
 
+func ExampleSeq_Distinct() {
+	words := func(yield func(string) bool) {
+		for _, w := range []string{"go", "iter", "go", "seq", "iter"} {
+			if !yield(w) {
+				return
+			}
+		}
+	}
+	for w := range stream.Of(words).Distinct().Iter() {
+		fmt.Println(w)
+	}
+	// Output:
+	// go
+	// iter
+	// seq
+}
+
+func ExampleSeq_Dedup() {
+	src := func(yield func(int) bool) {
+		for _, v := range []int{1, 1, 2, 2, 2, 1} {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+	for v := range stream.Of(src).Dedup().Iter() {
+		fmt.Println(v)
+	}
+	// Output:
+	// 1
+	// 2
+	// 1
+}
+
 func ExampleSeq_Take() {
 	s := stream.Of(xiter.Range1(5)).Take(2)
 	for v := range s.Iter() {
@@ -153,6 +189,75 @@ func ExampleSeq_StepBy() {
 	// 9
 }
 
+func ExampleSeq_Cycle() {
+	jobs := slices.Values([]string{"a", "b", "c", "d", "e"})
+	workers := stream.Of(slices.Values([]string{"w1", "w2", "w3"})).Cycle()
+	for job, worker := range xiter.Zip(jobs, workers.Iter()) {
+		fmt.Println(job, worker)
+	}
+	// Output:
+	// a w1
+	// b w2
+	// c w3
+	// d w1
+	// e w2
+}
+
+func ExampleSeq_CycleN() {
+	fmt.Println(slices.Collect(stream.Of(xiter.Range1(2)).CycleN(3).Iter()))
+	// Output:
+	// [0 1 0 1 0 1]
+}
+
+func ExampleSeq_Memoize() {
+	calls := 0
+	lines, stop := stream.FromFunc(func() (string, bool) {
+		calls++
+		return fmt.Sprintf("line %d", calls), calls <= 3
+	}).Memoize()
+	defer stop()
+	fmt.Println("count:", lines.Size())
+	lines.ForEach(func(l string) { fmt.Println(l) })
+	fmt.Println("supplier calls:", calls)
+	// Output:
+	// count: 3
+	// line 1
+	// line 2
+	// line 3
+	// supplier calls: 4
+}
+
+func ExampleSeq_Chunks() {
+	for c := range stream.Of(xiter.Range1(5)).Chunks(2) {
+		fmt.Println(c)
+	}
+	// Output:
+	// [0 1]
+	// [2 3]
+	// [4]
+}
+
+func ExampleSeq_Windows() {
+	for w := range stream.Of(xiter.Range1(4)).Windows(2, 1) {
+		fmt.Println(w)
+	}
+	// Output:
+	// [0 1]
+	// [1 2]
+	// [2 3]
+}
+
+func ExampleSeq_ChunkBy() {
+	s := stream.Of(xiter.Range1(7))
+	for c := range s.ChunkBy(func(prev, cur int) bool { return prev/3 == cur/3 }) {
+		fmt.Println(c)
+	}
+	// Output:
+	// [0 1 2]
+	// [3 4 5]
+	// [6]
+}
+
 func ExampleSeq_Chain() {
 	s := stream.Of(xiter.Range2(0, 2)).Chain(stream.Of(xiter.Range2(10, 12)))
 	for v := range s.Iter() {
@@ -165,6 +270,29 @@ func ExampleSeq_Chain() {
 	// 11
 }
 
+func ExampleSeq_Interleave() {
+	s := stream.Of(xiter.Range2(0, 3)).Interleave(stream.Of(xiter.Range2(10, 12)))
+	fmt.Println(slices.Collect(s.Iter()))
+	// Output:
+	// [0 10 1 11 2]
+}
+
+func ExampleSeq_Intersperse() {
+	fmt.Println(slices.Collect(stream.Of(xiter.Range1(3)).Intersperse(-1).Iter()))
+	// Output:
+	// [0 -1 1 -1 2]
+}
+
+func ExampleSeq_WithContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream.Of(xiter.Repeat(1)).WithContext(ctx).ForEach(func(v int) {
+		fmt.Println(v)
+		cancel()
+	})
+	// Output: 1
+}
+
 func ExampleSeq_Enumerate() {
 	s := stream.Of(xiter.Range2(10, 13)).Enumerate()
 	for k, v := range s.Iter() {
@@ -312,6 +440,19 @@ func ExampleSeq_MinMaxFunc() {
 	// Output: 1 5 true
 }
 
+func ExampleSeq_SortedFunc() {
+	s := stream.Of(slices.Values([]string{"pear", "fig", "apple", "kiwi"}))
+	byLen := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+	fmt.Println(slices.Collect(s.SortedStableFunc(byLen).Iter()))
+	// Output: [fig pear kiwi apple]
+}
+
+func ExampleSeq_TopK() {
+	s := stream.Of(slices.Values([]int{3, 1, 4, 1, 5, 9, 2, 6}))
+	fmt.Println(s.TopK(3, cmp.Compare), s.BottomK(3, cmp.Compare))
+	// Output: [9 6 5] [1 1 2]
+}
+
 func ExampleSeq_ContainsFunc() {
 	fmt.Println(stream.Of(xiter.Range1(5)).ContainsFunc(func(n int) bool { return n == 3 }))
 	// Output: true
@@ -496,6 +637,15 @@ func ExampleSeq2_StepBy() {
 	// 9:9
 }
 
+func ExampleSeq2_Chunks() {
+	for ks, vs := range stream.Of2(xiter.Enumerate(xiter.Range2(10, 13))).Chunks(2) {
+		fmt.Println(ks, vs)
+	}
+	// Output:
+	// [0 1] [10 11]
+	// [2] [12]
+}
+
 func ExampleSeq2_Chain() {
 	a := stream.Of2(xiter.Enumerate(xiter.Range2(0, 2)))
 	b := stream.Of2(xiter.Enumerate(xiter.Range2(10, 12)))
@@ -510,6 +660,21 @@ func ExampleSeq2_Chain() {
 	// 1:11
 }
 
+func ExampleSeq2_WithContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := stream.Of2(xiter.Enumerate(xiter.Range1(5))).WithContext(ctx)
+	for k, v := range s.Iter() {
+		fmt.Printf("%d:%d\n", k, v)
+		if k == 1 {
+			cancel()
+		}
+	}
+	// Output:
+	// 0:0
+	// 1:1
+}
+
 func ExampleSeq2_ForEach() {
 	stream.Of2(xiter.Enumerate(xiter.Range2(10, 13))).ForEach(func(k, v int) {
 		fmt.Printf("%d:%d\n", k, v)
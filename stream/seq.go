@@ -1,7 +1,9 @@
 package stream
 
 import (
+	"context"
 	"iter"
+	"math/rand/v2"
 
 	"github.com/go-board/xiter"
 )
@@ -70,6 +72,41 @@ func (s Seq[E]) Filter(f func(E) bool) Seq[E] { return Of(xiter.Filter(s.Iter(),
 // inside a lazy pipeline without consuming the sequence.
 func (s Seq[E]) Inspect(f func(E)) Seq[E] { return Of(xiter.Inspect(s.Iter(), f)) }
 
+// Distinct returns a Seq that yields each element the first time it appears
+// and drops later duplicates, preserving order. Because Seq[E] places no
+// constraint on E, elements are compared as interface values: Distinct panics
+// if an element's dynamic type is not comparable; DistinctBy is the
+// compile-time checked alternative. Every distinct element seen is
+// remembered; use DistinctLRU to bound memory.
+//
+//	Of(seqOf(1, 2, 1, 3)).Distinct()  // yields 1, 2, 3
+func (s Seq[E]) Distinct() Seq[E] {
+	return Of(xiter.DistinctBy(s.Iter(), func(e E) any { return e }))
+}
+
+// DistinctLRU is like Distinct but remembers only the size most recently seen
+// distinct elements, so memory stays bounded on long or infinite sequences.
+// Like Distinct, it panics if an element's dynamic type is not comparable.
+func (s Seq[E]) DistinctLRU(size int) Seq[E] {
+	return Of(xiter.DistinctByLRU(s.Iter(), func(e E) any { return e }, size))
+}
+
+// Dedup returns a Seq that collapses each run of consecutive equal elements
+// to its first element. Like Distinct, it compares elements as interface
+// values and panics if an element's dynamic type is not comparable; use
+// DedupFunc for element types that are not comparable.
+//
+//	Of(seqOf(1, 1, 2, 1)).Dedup()  // yields 1, 2, 1
+func (s Seq[E]) Dedup() Seq[E] {
+	return Of(xiter.DedupFunc(s.Iter(), func(a, b E) bool { return any(a) == any(b) }))
+}
+
+// DedupFunc is like Dedup but uses eq, called with the most recently yielded
+// element and the current one, to detect duplicates.
+func (s Seq[E]) DedupFunc(eq func(prev, cur E) bool) Seq[E] {
+	return Of(xiter.DedupFunc(s.Iter(), eq))
+}
+
 // Take returns a Seq yielding the first n elements, then stops. When n <= 0
 // the result is empty. When the source has fewer than n elements, all of them
 // are yielded. The source is released as soon as n elements have been yielded
@@ -104,12 +141,159 @@ func (s Seq[E]) SkipWhile(f func(E) bool) Seq[E] { return Of(xiter.SkipWhile(s.I
 //	Of(xiter.Range1(10)).StepBy(3)  // yields 0, 3, 6, 9
 func (s Seq[E]) StepBy(n int) Seq[E] { return Of(xiter.StepBy(s.Iter(), n)) }
 
+// SortedFunc returns a Seq over the elements of s sorted by comparator f.
+// Each iteration collects s into memory first; see xiter.SortedFunc.
+func (s Seq[E]) SortedFunc(f func(E, E) int) Seq[E] { return Of(xiter.SortedFunc(s.Iter(), f)) }
+
+// SortedStableFunc is like SortedFunc but keeps equal elements in their
+// original order.
+func (s Seq[E]) SortedStableFunc(f func(E, E) int) Seq[E] {
+	return Of(xiter.SortedStableFunc(s.Iter(), f))
+}
+
+// Bernoulli returns a Seq that yields each element of s independently with
+// probability p, drawing from rng; see xiter.Bernoulli.
+func (s Seq[E]) Bernoulli(p float64, rng *rand.Rand) Seq[E] {
+	return Of(xiter.Bernoulli(s.Iter(), p, rng))
+}
+
+// Cycle returns a Seq that replays s forever, calling s again for every pass.
+// It ends after a pass that yields nothing, so cycling an empty source
+// terminates. Use CycleBuffered when s cannot be iterated more than once.
+//
+//	Of(slices.Values([]int{1, 2})).Cycle().Take(5)  // yields 1, 2, 1, 2, 1
+func (s Seq[E]) Cycle() Seq[E] { return Of(xiter.Cycle(s.Iter())) }
+
+// CycleN returns a Seq that replays s n times. When n <= 0 the result is
+// empty.
+func (s Seq[E]) CycleN(n int) Seq[E] { return Of(xiter.CycleN(s.Iter(), n)) }
+
+// CycleBuffered returns a Seq that iterates s once, buffering its elements,
+// and replays the buffer forever afterwards. The buffer is shared by every
+// iteration of the returned Seq.
+func (s Seq[E]) CycleBuffered() Seq[E] { return Of(xiter.CycleBuffered(s.Iter())) }
+
+// Memoize returns a Seq that iterates s at most once and replays the recorded
+// elements on later iterations, so a pipeline over a single-use or
+// side-effecting source can be consumed several times, together with a
+// function that releases s. It is safe for concurrent iteration; see
+// xiter.Memoize for when to call stop.
+//
+//	m, stop := Of(expensive).Memoize()
+//	defer stop()
+//	n := m.Size()      // pulls from expensive
+//	m.ForEach(report)  // replays without pulling again
+func (s Seq[E]) Memoize() (Seq[E], func()) {
+	m, stop := xiter.Memoize(s.Iter())
+	return Of(m), stop
+}
+
+// TryMemoizeLimit is like Memoize but records at most limit elements and
+// yields each element paired with a nil error; see xiter.TryMemoizeLimit for
+// how a pass past the limit reports ErrMemoizeLimit.
+func (s Seq[E]) TryMemoizeLimit(limit int) (Seq2[E, error], func()) {
+	m, stop := xiter.TryMemoizeLimit(s.Iter(), limit)
+	return Of2(m), stop
+}
+
+// Buffered returns a Seq that runs s on its own goroutine, up to n elements
+// ahead of the consumer; see xiter.Buffered.
+func (s Seq[E]) Buffered(n int) Seq[E] { return Of(xiter.Buffered(s.Iter(), n)) }
+
+// Chunks returns a sequence of consecutive chunks of n elements; the last
+// chunk may be shorter. When n <= 0 the result is empty. Each chunk is a
+// freshly allocated slice. The result is a plain iter.Seq because a method of
+// Seq[E] cannot return Seq[[]E]; wrap it with Of to keep chaining.
+//
+//	Of(xiter.Range1(5)).Chunks(2)  // yields [0 1], [2 3], [4]
+func (s Seq[E]) Chunks(n int) iter.Seq[[]E] { return xiter.Chunks(s.Iter(), n) }
+
+// ChunksReuse is like Chunks but yields the same backing buffer for every
+// chunk; a yielded slice is only valid until the next iteration step.
+func (s Seq[E]) ChunksReuse(n int) iter.Seq[[]E] { return xiter.ChunksReuse(s.Iter(), n) }
+
+// Windows returns a sequence of sliding windows of exactly size elements whose
+// start advances by step. When size <= 0 or step <= 0 the result is empty.
+// Each window is a freshly allocated slice. Like Chunks, it returns a plain
+// iter.Seq.
+//
+//	Of(xiter.Range1(5)).Windows(3, 1)  // yields [0 1 2], [1 2 3], [2 3 4]
+func (s Seq[E]) Windows(size, step int) iter.Seq[[]E] {
+	return xiter.Windows(s.Iter(), size, step)
+}
+
+// WindowsReuse is like Windows but yields the same backing buffer for every
+// window; a yielded slice is only valid until the next iteration step.
+func (s Seq[E]) WindowsReuse(size, step int) iter.Seq[[]E] {
+	return xiter.WindowsReuse(s.Iter(), size, step)
+}
+
+// ChunkBy returns a sequence of runs of adjacent elements for which f(prev,
+// cur) reports true. Each chunk is a freshly allocated slice. Like Chunks, it
+// returns a plain iter.Seq.
+func (s Seq[E]) ChunkBy(f func(prev, cur E) bool) iter.Seq[[]E] {
+	return xiter.ChunkBy(s.Iter(), f)
+}
+
+// ChunkByReuse is like ChunkBy but yields the same backing buffer for every
+// chunk; a yielded slice is only valid until the next iteration step.
+func (s Seq[E]) ChunkByReuse(f func(prev, cur E) bool) iter.Seq[[]E] {
+	return xiter.ChunkByReuse(s.Iter(), f)
+}
+
 // Chain concatenates s and other into a single Seq: all elements of s first,
 // then all elements of other. Either side may be empty or infinite.
 //
 //	Of(seqOf(1, 2)).Chain(Of(seqOf(10, 11)))  // yields 1, 2, 10, 11
 func (s Seq[E]) Chain(other Seq[E]) Seq[E] { return Of(xiter.Chain(s.Iter(), other.Iter())) }
 
+// Interleave returns a Seq that takes one element from s and then from each
+// of others in turn, round-robin. Sources that run out are dropped from the
+// rotation while the rest continue.
+//
+//	Of(seqOf(1, 2, 3)).Interleave(Of(seqOf(10)))  // yields 1, 10, 2, 3
+func (s Seq[E]) Interleave(others ...Seq[E]) Seq[E] {
+	return Of(xiter.Interleave(interleaveSources(s, others)...))
+}
+
+// InterleaveShortest is like Interleave but yields whole rounds only: it ends
+// at the first round in which any source runs out, discarding the elements
+// already pulled in that round.
+//
+//	Of(seqOf(1, 2, 3)).InterleaveShortest(Of(seqOf(10)))  // yields 1, 10
+func (s Seq[E]) InterleaveShortest(others ...Seq[E]) Seq[E] {
+	return Of(xiter.InterleaveShortest(interleaveSources(s, others)...))
+}
+
+func interleaveSources[E any](s Seq[E], others []Seq[E]) []iter.Seq[E] {
+	seqs := make([]iter.Seq[E], 0, len(others)+1)
+	seqs = append(seqs, s.Iter())
+	for _, o := range others {
+		seqs = append(seqs, o.Iter())
+	}
+	return seqs
+}
+
+// Intersperse returns a Seq with sep inserted between each pair of adjacent
+// elements of s.
+//
+//	Of(seqOf("a", "b", "c")).Intersperse(",")  // yields "a", ",", "b", ",", "c"
+func (s Seq[E]) Intersperse(sep E) Seq[E] { return Of(xiter.Intersperse(s.Iter(), sep)) }
+
+// IntersperseWith is like Intersperse but calls f for every separator.
+func (s Seq[E]) IntersperseWith(f func() E) Seq[E] {
+	return Of(xiter.IntersperseWith(s.Iter(), f))
+}
+
+// WithContext returns a Seq that yields elements of s until ctx is cancelled
+// or its deadline passes, then stops. The context is checked between
+// elements only; a source blocked inside its own code is not interrupted.
+//
+//	Of(xiter.Repeat(1)).WithContext(ctx).ForEach(handle)  // stops on cancel
+func (s Seq[E]) WithContext(ctx context.Context) Seq[E] {
+	return Of(xiter.WithContext(ctx, s.Iter()))
+}
+
 // Enumerate converts s into a Seq2[int, E] whose key is the zero-based index
 // of each element and whose value is the element itself.
 //
@@ -237,6 +421,19 @@ func (s Seq[E]) MinMaxFunc(f func(E, E) int) (E, E, bool) {
 	return xiter.MinMaxFunc(s.Iter(), f)
 }
 
+// TopK is a terminal operation that returns the k largest elements of s by
+// comparator f, largest first, keeping only k elements in memory. Ties are
+// stable by arrival.
+func (s Seq[E]) TopK(k int, f func(E, E) int) []E { return xiter.TopK(s.Iter(), k, f) }
+
+// BottomK is a terminal operation that returns the k smallest elements of s
+// by comparator f, smallest first. Ties are stable by arrival.
+func (s Seq[E]) BottomK(k int, f func(E, E) int) []E { return xiter.BottomK(s.Iter(), k, f) }
+
+// Sample is a terminal operation that returns k elements of s chosen
+// uniformly at random, drawing from rng; see xiter.Sample.
+func (s Seq[E]) Sample(k int, rng *rand.Rand) []E { return xiter.Sample(s.Iter(), k, rng) }
+
 // ContainsFunc is a terminal operation that reports whether any element of s
 // satisfies f. It stops as soon as f returns true. Returns false for an empty
 // sequence. Equivalent to Any but named to mirror the Contains family.
@@ -1,7 +1,9 @@
 package stream
 
 import (
+	"context"
 	"iter"
+	"math/rand/v2"
 
 	"github.com/go-board/xiter"
 )
@@ -86,6 +88,20 @@ func (s Seq2[K, V]) Inspect(f func(K, V)) Seq2[K, V] {
 	return Of2(xiter.Inspect2(s.Iter(), f))
 }
 
+// Distinct returns a Seq2 that yields the first pair for each key and drops
+// later pairs whose key was already seen. Keys are compared as interface
+// values: Distinct panics if a key's dynamic type is not comparable.
+// DistinctBy is the compile-time checked alternative.
+func (s Seq2[K, V]) Distinct() Seq2[K, V] {
+	return Of2(xiter.DistinctBy2(s.Iter(), func(k K, _ V) any { return k }))
+}
+
+// DedupFunc returns a Seq2 that drops each pair for which eq, called with the
+// most recently yielded pair and the current one, reports true.
+func (s Seq2[K, V]) DedupFunc(eq func(prevK K, prevV V, curK K, curV V) bool) Seq2[K, V] {
+	return Of2(xiter.DedupFunc2(s.Iter(), eq))
+}
+
 // Take returns a Seq2 yielding the first n pairs, then stops. When n <= 0 the
 // result is empty. When the source has fewer than n pairs, all of them are
 // yielded.
@@ -117,12 +133,87 @@ func (s Seq2[K, V]) SkipWhile(f func(K, V) bool) Seq2[K, V] {
 //	// yields (0,0), (3,3), (6,6), (9,9)
 func (s Seq2[K, V]) StepBy(n int) Seq2[K, V] { return Of2(xiter.StepBy2(s.Iter(), n)) }
 
+// Bernoulli returns a Seq2 that yields each pair of s independently with
+// probability p, drawing from rng; see xiter.Bernoulli2.
+func (s Seq2[K, V]) Bernoulli(p float64, rng *rand.Rand) Seq2[K, V] {
+	return Of2(xiter.Bernoulli2(s.Iter(), p, rng))
+}
+
+// Cycle returns a Seq2 that replays s forever, calling s again for every
+// pass. It ends after a pass that yields nothing.
+func (s Seq2[K, V]) Cycle() Seq2[K, V] { return Of2(xiter.Cycle2(s.Iter())) }
+
+// CycleN returns a Seq2 that replays s n times. When n <= 0 the result is
+// empty.
+func (s Seq2[K, V]) CycleN(n int) Seq2[K, V] { return Of2(xiter.CycleN2(s.Iter(), n)) }
+
+// CycleBuffered returns a Seq2 that iterates s once, buffering its pairs, and
+// replays the buffer forever afterwards. The buffer is shared by every
+// iteration of the returned Seq2.
+func (s Seq2[K, V]) CycleBuffered() Seq2[K, V] { return Of2(xiter.CycleBuffered2(s.Iter())) }
+
+// Memoize returns a Seq2 that iterates s at most once and replays the
+// recorded pairs on later iterations, together with a function that releases
+// s. It is safe for concurrent iteration.
+func (s Seq2[K, V]) Memoize() (Seq2[K, V], func()) {
+	m, stop := xiter.Memoize2(s.Iter())
+	return Of2(m), stop
+}
+
+// Buffered returns a Seq2 that runs s on its own goroutine, up to n pairs
+// ahead of the consumer; see xiter.Buffered2.
+func (s Seq2[K, V]) Buffered(n int) Seq2[K, V] { return Of2(xiter.Buffered2(s.Iter(), n)) }
+
+// Chunks returns a sequence of consecutive chunks of n pairs, each yielded as
+// parallel key and value slices; the last chunk may be shorter. When n <= 0
+// the result is empty. The result is a plain iter.Seq2 because a method of
+// Seq2[K, V] cannot return Seq2[[]K, []V]; wrap it with Of2 to keep chaining.
+func (s Seq2[K, V]) Chunks(n int) iter.Seq2[[]K, []V] { return xiter.Chunks2(s.Iter(), n) }
+
+// ChunksReuse is like Chunks but yields the same two backing buffers for every
+// chunk; the yielded slices are only valid until the next iteration step.
+func (s Seq2[K, V]) ChunksReuse(n int) iter.Seq2[[]K, []V] {
+	return xiter.ChunksReuse2(s.Iter(), n)
+}
+
+// Windows returns a sequence of sliding windows of exactly size pairs whose
+// start advances by step, each yielded as parallel key and value slices.
+func (s Seq2[K, V]) Windows(size, step int) iter.Seq2[[]K, []V] {
+	return xiter.Windows2(s.Iter(), size, step)
+}
+
+// WindowsReuse is like Windows but yields the same two backing buffers for
+// every window; the yielded slices are only valid until the next iteration
+// step.
+func (s Seq2[K, V]) WindowsReuse(size, step int) iter.Seq2[[]K, []V] {
+	return xiter.WindowsReuse2(s.Iter(), size, step)
+}
+
+// ChunkBy returns a sequence of runs of adjacent pairs for which f reports
+// true, each yielded as parallel key and value slices.
+func (s Seq2[K, V]) ChunkBy(f func(prevK K, prevV V, curK K, curV V) bool) iter.Seq2[[]K, []V] {
+	return xiter.ChunkBy2(s.Iter(), f)
+}
+
+// ChunkByReuse is like ChunkBy but yields the same two backing buffers for
+// every chunk; the yielded slices are only valid until the next iteration
+// step.
+func (s Seq2[K, V]) ChunkByReuse(f func(prevK K, prevV V, curK K, curV V) bool) iter.Seq2[[]K, []V] {
+	return xiter.ChunkByReuse2(s.Iter(), f)
+}
+
 // Chain concatenates s and other into a single Seq2: all pairs of s first,
 // then all pairs of other. Either side may be empty or infinite.
 func (s Seq2[K, V]) Chain(other Seq2[K, V]) Seq2[K, V] {
 	return Of2(xiter.Chain2(s.Iter(), other.Iter()))
 }
 
+// WithContext returns a Seq2 that yields pairs of s until ctx is cancelled or
+// its deadline passes, then stops. The context is checked between pairs only.
+func (s Seq2[K, V]) WithContext(ctx context.Context) Seq2[K, V] {
+	return Of2(xiter.WithContext2(ctx, s.Iter()))
+}
+
 // ForEach is a terminal operation that consumes s and calls f for each pair.
 // It has no return value. The sequence is fully consumed unless f panics.
 func (s Seq2[K, V]) ForEach(f func(K, V)) { xiter.ForEach2(s.Iter(), f) }
@@ -43,6 +43,15 @@ func (s Seq2[K, V]) FilterMap[K2, V2 any](f func(K, V) (K2, V2, bool)) Seq2[K2,
 	return Of2(xiter.FilterMap2(s.Iter(), f))
 }
 
+// DistinctBy returns a Seq2 that yields the first pair for each key returned
+// by key and drops later pairs with an already seen key. Unlike Distinct, it
+// is checked at compile time and works for key types that are not comparable.
+// Requires Go 1.27 method-level generics because the key type is independent
+// of K and V.
+func (s Seq2[K, V]) DistinctBy[C comparable](key func(K, V) C) Seq2[K, V] {
+	return Of2(xiter.DistinctBy2(s.Iter(), key))
+}
+
 // Join turns each key/value pair of s into a single element by applying f,
 // producing a Seq[E]. It is the inverse direction of Split. Requires Go 1.27
 // method-level generics because the output type changes to Seq.
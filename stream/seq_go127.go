@@ -41,6 +41,19 @@ func (s Seq[E]) FilterMap[E2 any](f func(E) (E2, bool)) Seq[E2] {
 	return Of(xiter.FilterMap(s.Iter(), f))
 }
 
+// DistinctBy returns a Seq that yields the first element for each key
+// returned by key and drops later elements with an already seen key,
+// preserving order. Unlike Distinct, it is checked at compile time and works
+// for element types that are not comparable. Requires Go 1.27 method-level
+// generics because the key type is independent of E.
+//
+//	Of(seqOf([]int{1}, []int{2, 3}, []int{4})).DistinctBy(func(v []int) int {
+//	    return len(v)
+//	})  // yields [1], [2 3]
+func (s Seq[E]) DistinctBy[K comparable](key func(E) K) Seq[E] {
+	return Of(xiter.DistinctBy(s.Iter(), key))
+}
+
 // Split turns each element of s into a (key, value) pair by applying f,
 // producing a Seq2[K, V]. It is the inverse direction of Join. Requires Go 1.27
 // method-level generics because the output type changes to Seq2.
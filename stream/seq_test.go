@@ -316,6 +316,22 @@ func TestSeq2KeyValueMethods(t *testing.T) {
 	}
 }
 
+func TestSeqDistinctBy(t *testing.T) {
+	src := Of(slices.Values([][]int{{1}, {2, 3}, {4}, {5, 6}}))
+	got := slices.Collect(src.DistinctBy(func(v []int) int { return len(v) }).Iter())
+	if len(got) != 2 || !slices.Equal(got[0], []int{1}) || !slices.Equal(got[1], []int{2, 3}) {
+		t.Fatalf("got %v, want [[1] [2 3]]", got)
+	}
+
+	pairs := Of2(slices.All([][]int{{1}, {2}, {3, 4}})).
+		DistinctBy(func(_ int, v []int) int { return len(v) }).
+		Keys().
+		Collect(slices.Collect)
+	if !slices.Equal(pairs, []int{0, 2}) {
+		t.Fatalf("keys %v, want [0 2]", pairs)
+	}
+}
+
 func TestSeqCollect(t *testing.T) {
 	// stdlib slices.Collect
 	got := Of(xiter.Range1(5)).Collect(slices.Collect)
@@ -0,0 +1,267 @@
+package xiter
+
+import (
+	"errors"
+	"iter"
+	"sync"
+)
+
+// ============================================================================
+// Tee
+// ============================================================================
+
+// ErrTeeLimit is yielded by a TryTee reader that would have to buffer more
+// than the configured limit of elements to advance, because another reader
+// has fallen too far behind.
+var ErrTeeLimit = errors.New("xiter: tee reader exceeded the buffer limit")
+
+// Tee splits s into n independent readers that each yield every element of
+// s, in order, while s itself is iterated only once. It lets several
+// consumers share a single-use source such as a network stream or a FromFunc
+// supplier without materializing it first. When n <= 0 the result is nil.
+//
+// The readers share one buffer that holds only the elements some reader has
+// not consumed yet; a reader that finishes or breaks early stops holding
+// elements back. The buffer is unbounded, so a reader that is never iterated
+// makes the buffer grow to the whole source; use TeeBlocking or TryTee to
+// bound it.
+//
+// s is pulled lazily, only when a reader runs past the buffered elements,
+// and is released once every reader has finished or broken early. Readers
+// may be iterated from different goroutines. Each reader may be iterated
+// only once; later iterations yield nothing.
+//
+//	r := Tee(Range1(3), 2)
+//	// r[0] yields 0, 1, 2 and r[1] independently yields 0, 1, 2
+func Tee[E any](s iter.Seq[E], n int) []iter.Seq[E] {
+	return teeReaders(newTee(s, n, 0, true))
+}
+
+// TeeBlocking is like Tee but bounds the shared buffer to limit elements: a
+// reader that is limit elements ahead of the slowest unfinished reader blocks
+// until that reader catches up. The readers must therefore be iterated on
+// different goroutines; iterating them one after the other on the same
+// goroutine deadlocks once the buffer fills. When limit <= 0 the buffer is
+// unbounded, as with Tee.
+func TeeBlocking[E any](s iter.Seq[E], n, limit int) []iter.Seq[E] {
+	return teeReaders(newTee(s, n, limit, true))
+}
+
+// TryTee is like Tee but bounds the shared buffer to limit elements. A reader
+// that is limit elements ahead of the slowest unfinished reader yields a
+// zero value together with ErrTeeLimit and stops, instead of growing the
+// buffer further. All other elements are yielded as (value, nil) pairs. When
+// limit <= 0 the buffer is unbounded and no error is ever yielded.
+//
+//	r := TryTee(Range1(5), 2, 2)
+//	// r[0] yields (0,nil), (1,nil), (0,ErrTeeLimit) while r[1] is idle
+func TryTee[E any](s iter.Seq[E], n, limit int) []iter.Seq2[E, error] {
+	t := newTee(s, n, limit, false)
+	if t == nil {
+		return nil
+	}
+	readers := make([]iter.Seq2[E, error], n)
+	for i := range readers {
+		readers[i] = func(yield func(E, error) bool) {
+			defer t.detach(i)
+			for {
+				e, ok, err := t.next(i)
+				if err != nil {
+					var zero E
+					yield(zero, err)
+					return
+				}
+				if !ok || !yield(e, nil) {
+					return
+				}
+			}
+		}
+	}
+	return readers
+}
+
+// Tee2 is the iter.Seq2 variant of Tee: it splits a key/value sequence into n
+// independent readers while iterating s only once.
+func Tee2[K, V any](s iter.Seq2[K, V], n int) []iter.Seq2[K, V] {
+	return unzipReaders(Tee(zipPairs(s), n))
+}
+
+// TeeBlocking2 is the iter.Seq2 variant of TeeBlocking.
+func TeeBlocking2[K, V any](s iter.Seq2[K, V], n, limit int) []iter.Seq2[K, V] {
+	return unzipReaders(TeeBlocking(zipPairs(s), n, limit))
+}
+
+func unzipReaders[K, V any](readers []iter.Seq[pair[K, V]]) []iter.Seq2[K, V] {
+	if readers == nil {
+		return nil
+	}
+	out := make([]iter.Seq2[K, V], len(readers))
+	for i, r := range readers {
+		out[i] = func(yield func(K, V) bool) {
+			for p := range r {
+				if !yield(p.k, p.v) {
+					return
+				}
+			}
+		}
+	}
+	return out
+}
+
+func teeReaders[E any](t *tee[E]) []iter.Seq[E] {
+	if t == nil {
+		return nil
+	}
+	readers := make([]iter.Seq[E], len(t.pos))
+	for i := range readers {
+		readers[i] = func(yield func(E) bool) {
+			defer t.detach(i)
+			for {
+				e, ok, _ := t.next(i)
+				if !ok || !yield(e) {
+					return
+				}
+			}
+		}
+	}
+	return readers
+}
+
+// tee is the state shared by the readers of Tee, TeeBlocking and TryTee.
+// Positions are absolute indices into the source; buf holds the elements
+// from index base onwards that some active reader has not consumed yet.
+type tee[E any] struct {
+	mu   sync.Mutex
+	cond sync.Cond
+
+	src  iter.Seq[E]
+	pull func() (E, bool) // set by the first pull
+	stop func()
+
+	buf  []E
+	base int
+	pos  []int  // next index for each reader
+	done []bool // whether each reader has finished or broken early
+	live int    // number of readers that are not done
+
+	limit   int
+	block   bool
+	pulling bool // a reader is pulling from the source outside mu
+	ended   bool // the source is exhausted or released
+}
+
+func newTee[E any](s iter.Seq[E], n, limit int, block bool) *tee[E] {
+	if n <= 0 {
+		return nil
+	}
+	t := &tee[E]{
+		pos:   make([]int, n),
+		done:  make([]bool, n),
+		live:  n,
+		src:   s,
+		limit: limit,
+		block: block,
+	}
+	t.cond.L = &t.mu
+	return t
+}
+
+// next returns the next element for reader i, pulling from the source when
+// the reader has consumed every buffered element. ok is false once the source
+// is exhausted or the reader has already finished; err is ErrTeeLimit when a
+// non-blocking tee would exceed its limit.
+func (t *tee[E]) next(i int) (e E, ok bool, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for {
+		if t.done[i] {
+			return e, false, nil
+		}
+		if idx := t.pos[i] - t.base; idx < len(t.buf) {
+			e = t.buf[idx]
+			t.pos[i]++
+			t.trim()
+			return e, true, nil
+		}
+		if t.ended {
+			return e, false, nil
+		}
+		if t.limit > 0 && len(t.buf) >= t.limit {
+			if !t.block {
+				return e, false, ErrTeeLimit
+			}
+			t.cond.Wait()
+			continue
+		}
+		if t.pulling {
+			t.cond.Wait()
+			continue
+		}
+		v, more := t.pullUnlocked()
+		if more {
+			t.buf = append(t.buf, v)
+		} else {
+			t.ended = true
+			t.stop()
+		}
+		t.cond.Broadcast()
+	}
+}
+
+// pullUnlocked pulls the next element from the source with mu released, so
+// that other readers can keep draining the buffer while the source produces
+// it. It must be called with mu held and returns with mu held, even if the
+// source panics.
+func (t *tee[E]) pullUnlocked() (E, bool) {
+	if t.pull == nil {
+		t.pull, t.stop = iter.Pull(t.src)
+	}
+	t.pulling = true
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		t.pulling = false
+	}()
+	return t.pull()
+}
+
+// detach marks reader i as done so it no longer holds elements in the
+// buffer, and releases the source once every reader is done.
+func (t *tee[E]) detach(i int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done[i] {
+		return
+	}
+	t.done[i] = true
+	t.live--
+	if t.live == 0 {
+		t.buf = nil
+		if !t.ended && t.stop != nil {
+			t.stop()
+		}
+		t.ended = true
+	} else {
+		t.trim()
+	}
+	t.cond.Broadcast()
+}
+
+// trim drops the buffered elements that every active reader has consumed.
+// It must be called with mu held.
+func (t *tee[E]) trim() {
+	low := -1
+	for j, p := range t.pos {
+		if !t.done[j] && (low < 0 || p < low) {
+			low = p
+		}
+	}
+	if low <= t.base {
+		return
+	}
+	k := low - t.base
+	clear(t.buf[:k])
+	t.buf = t.buf[k:]
+	t.base = low
+	t.cond.Broadcast()
+}
@@ -0,0 +1,231 @@
+package xiter
+
+import (
+	"errors"
+	"iter"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// onceSource returns a single-use source over vs that fails the test if it is
+// iterated twice, and reports whether its iteration has returned.
+func onceSource(t *testing.T, vs ...int) (iter.Seq[int], *bool) {
+	t.Helper()
+	started, released := false, false
+	return func(yield func(int) bool) {
+		if started {
+			t.Error("source iterated more than once")
+			return
+		}
+		started = true
+		defer func() { released = true }()
+		for _, v := range vs {
+			if !yield(v) {
+				return
+			}
+		}
+	}, &released
+}
+
+func TestTee(t *testing.T) {
+	src, released := onceSource(t, 1, 2, 3, 4)
+	r := Tee(src, 3)
+	if len(r) != 3 {
+		t.Fatalf("got %d readers, want 3", len(r))
+	}
+	want := []int{1, 2, 3, 4}
+	for i, reader := range r[:2] {
+		if got := ToSlice(reader); !reflect.DeepEqual(got, want) {
+			t.Fatalf("reader %d got %v, want %v", i, got, want)
+		}
+	}
+	if !*released {
+		t.Fatal("source not released after it was exhausted")
+	}
+	if got := ToSlice(r[2]); !reflect.DeepEqual(got, want) {
+		t.Fatalf("reader 2 got %v, want %v", got, want)
+	}
+	if got := ToSlice(r[0]); len(got) != 0 {
+		t.Fatalf("second iteration of a reader got %v, want empty", got)
+	}
+	if r := Tee(Range1(3), 0); r != nil {
+		t.Fatalf("got %d readers, want nil", len(r))
+	}
+}
+
+func TestTeeInterleaved(t *testing.T) {
+	src, _ := onceSource(t, 1, 2, 3)
+	r := Tee(src, 2)
+	next0, stop0 := iter.Pull(r[0])
+	defer stop0()
+	next1, stop1 := iter.Pull(r[1])
+	defer stop1()
+	var got0, got1 []int
+	for {
+		v0, ok0 := next0()
+		v1, ok1 := next1()
+		if !ok0 || !ok1 {
+			if ok0 != ok1 {
+				t.Fatal("readers ended at different positions")
+			}
+			break
+		}
+		got0 = append(got0, v0)
+		got1 = append(got1, v1)
+	}
+	want := []int{1, 2, 3}
+	if !reflect.DeepEqual(got0, want) || !reflect.DeepEqual(got1, want) {
+		t.Fatalf("got %v and %v, want %v", got0, got1, want)
+	}
+}
+
+func TestTeeBuffer(t *testing.T) {
+	tt := newTee(Range1(10), 2, 0, true)
+	r := teeReaders(tt)
+	if got := ToSlice(Take(r[0], 6)); len(got) != 6 {
+		t.Fatalf("got %v, want 6 elements", got)
+	}
+	// Reader 0 broke early, so only reader 1 holds elements back.
+	if len(tt.buf) != 6 {
+		t.Fatalf("buffer holds %d elements, want 6", len(tt.buf))
+	}
+	for v := range r[1] {
+		if v == 3 {
+			break
+		}
+	}
+	if tt.buf != nil || !tt.ended {
+		t.Fatalf("buffer %v not released after every reader stopped", tt.buf)
+	}
+}
+
+func TestTeeEarlyStop(t *testing.T) {
+	src, released := onceSource(t, 1, 2, 3)
+	r := Tee(src, 2)
+	stopEarly(r[0])
+	if *released {
+		t.Fatal("source released while a reader is still pending")
+	}
+	for range r[1] {
+		break
+	}
+	if !*released {
+		t.Fatal("source not released after every reader stopped")
+	}
+}
+
+func TestTryTee(t *testing.T) {
+	r := TryTee(Range1(5), 2, 2)
+	var vals []int
+	var errs []error
+	for v, err := range r[0] {
+		vals = append(vals, v)
+		errs = append(errs, err)
+	}
+	if !reflect.DeepEqual(vals, []int{0, 1, 0}) || errs[0] != nil || errs[1] != nil || !errors.Is(errs[2], ErrTeeLimit) {
+		t.Fatalf("got %v %v", vals, errs)
+	}
+	// The lagging reader is unaffected and, alone, no longer bounded.
+	got, err := CollectErr(r[1])
+	if !reflect.DeepEqual(got, []int{0, 1, 2, 3, 4}) || err != nil {
+		t.Fatalf("got (%v, %v), want ([0 1 2 3 4], nil)", got, err)
+	}
+
+	got, err = CollectErr(TryTee(Range1(3), 2, 0)[0])
+	if !reflect.DeepEqual(got, []int{0, 1, 2}) || err != nil {
+		t.Fatalf("unbounded got (%v, %v)", got, err)
+	}
+	stopEarly2(TryTee(Range1(3), 1, 1)[0])
+}
+
+func TestTeeBlocking(t *testing.T) {
+	const n, limit = 4, 3
+	tt := newTee(Range1(200), n, limit, true)
+	r := teeReaders(tt)
+	got := make([][]int, n)
+	var wg sync.WaitGroup
+	for i := range r {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range r[i] {
+				tt.mu.Lock()
+				if len(tt.buf) > limit {
+					t.Errorf("buffer holds %d elements, limit is %d", len(tt.buf), limit)
+				}
+				tt.mu.Unlock()
+				got[i] = append(got[i], v)
+			}
+		}()
+	}
+	wg.Wait()
+	want := ToSlice(Range1(200))
+	for i := range got {
+		if !reflect.DeepEqual(got[i], want) {
+			t.Fatalf("reader %d got %v", i, got[i])
+		}
+	}
+}
+
+func TestTeeSourcePanic(t *testing.T) {
+	pulling, release := make(chan struct{}), make(chan struct{})
+	src := func(yield func(int) bool) {
+		if !yield(1) {
+			return
+		}
+		close(pulling)
+		<-release
+		panic("boom")
+	}
+	r := Tee(src, 2)
+
+	panicked := make(chan any)
+	go func() {
+		defer func() { panicked <- recover() }()
+		for range r[0] {
+		}
+	}()
+	<-pulling
+
+	// The second reader waits for the first one's pull, which panics; the
+	// first reader detaching must wake it, and it then sees the source end.
+	got := make(chan []int)
+	go func() { got <- ToSlice(r[1]) }()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	if p := <-panicked; p != "boom" {
+		t.Fatalf("first reader recovered %v, want boom", p)
+	}
+	select {
+	case vs := <-got:
+		if !reflect.DeepEqual(vs, []int{1}) {
+			t.Fatalf("second reader got %v, want [1]", vs)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("second reader still blocked after the source panicked")
+	}
+}
+
+func TestTee2(t *testing.T) {
+	type kv = struct {
+		K string
+		V int
+	}
+	r := Tee2(seq2Of(kv{"a", 1}, kv{"b", 2}), 2)
+	want := map[string]int{"a": 1, "b": 2}
+	for i, reader := range r {
+		if got := ToMap(reader); !reflect.DeepEqual(got, want) {
+			t.Fatalf("reader %d got %v, want %v", i, got, want)
+		}
+	}
+	if r := Tee2(seq2Of[string, int](), 0); r != nil {
+		t.Fatalf("got %d readers, want nil", len(r))
+	}
+	r = TeeBlocking2(seq2Of(kv{"a", 1}), 2, 1)
+	stopEarly2(r[0])
+	if got := ToMap(r[1]); !reflect.DeepEqual(got, map[string]int{"a": 1}) {
+		t.Fatalf("got %v", got)
+	}
+}
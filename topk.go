@@ -0,0 +1,104 @@
+package xiter
+
+import (
+	"cmp"
+	"iter"
+)
+
+// ============================================================================
+// Top-k
+// ============================================================================
+
+// TopK returns the k largest elements of s according to cmp, largest first.
+// It consumes s entirely but keeps only k elements in memory, in a heap,
+// taking O(n log k) time. Ties are stable by arrival: of two equal elements
+// the earlier one ranks higher, so it is the one kept when only one fits and
+// it comes first in the result. The result is shorter than k when s is, and
+// nil when k <= 0 or s is empty.
+//
+//	TopK(seqOf(3, 1, 4, 1, 5, 9, 2, 6), 3, cmp.Compare[int])  // returns [9 6 5]
+func TopK[E any](s iter.Seq[E], k int, cmp func(E, E) int) []E {
+	return topK(s, k, cmp)
+}
+
+// BottomK returns the k smallest elements of s according to cmp, smallest
+// first, using O(k) memory like TopK. Ties are stable by arrival: of two equal
+// elements the earlier one is kept and comes first.
+//
+//	BottomK(seqOf(3, 1, 4, 1, 5, 9, 2, 6), 3, cmp.Compare[int])  // returns [1 1 2]
+func BottomK[E any](s iter.Seq[E], k int, cmp func(E, E) int) []E {
+	return topK(s, k, func(a, b E) int { return cmp(b, a) })
+}
+
+// TopKBy returns the k elements of s with the largest key, largest first,
+// calling key once per element. It otherwise behaves like TopK.
+//
+//	hits := map[string]int{"/a": 3, "/b": 10, "/c": 7}
+//	TopKBy(maps.Keys(hits), 2, func(p string) int { return hits[p] })
+//	// returns [/b /c]
+func TopKBy[E any, K cmp.Ordered](s iter.Seq[E], k int, key func(E) K) []E {
+	return byKey(s, k, key, func(a, b K) int { return cmp.Compare(a, b) })
+}
+
+// BottomKBy returns the k elements of s with the smallest key, smallest
+// first, calling key once per element. It otherwise behaves like BottomK.
+func BottomKBy[E any, K cmp.Ordered](s iter.Seq[E], k int, key func(E) K) []E {
+	return byKey(s, k, key, func(a, b K) int { return cmp.Compare(b, a) })
+}
+
+// byKey runs topK over (key, element) pairs so that key is called only once
+// per element.
+func byKey[E any, K cmp.Ordered](s iter.Seq[E], k int, key func(E) K, cmpKey func(K, K) int) []E {
+	keyed := Map(s, func(e E) pair[K, E] { return pair[K, E]{key(e), e} })
+	top := topK(keyed, k, func(a, b pair[K, E]) int { return cmpKey(a.k, b.k) })
+	if top == nil {
+		return nil
+	}
+	out := make([]E, len(top))
+	for i, kv := range top {
+		out[i] = kv.v
+	}
+	return out
+}
+
+// ranked is an element tagged with its position in the source, which breaks
+// ties between equal elements.
+type ranked[E any] struct {
+	e   E
+	seq int
+}
+
+// topK keeps the k highest-ranked elements of s by cmp in a min-heap whose
+// root is the lowest-ranked element kept: the smallest, and among equals the
+// latest to arrive.
+func topK[E any](s iter.Seq[E], k int, cmp func(E, E) int) []E {
+	if k <= 0 {
+		return nil
+	}
+	h := binaryHeap[ranked[E]]{less: func(a, b ranked[E]) bool {
+		if c := cmp(a.e, b.e); c != 0 {
+			return c < 0
+		}
+		return a.seq > b.seq
+	}}
+	seq := 0
+	for e := range s {
+		switch {
+		case h.len() < k:
+			h.push(ranked[E]{e, seq})
+		case cmp(e, h.top().e) > 0:
+			// An equal element arrived later and ranks lower, so only a
+			// strictly greater one displaces the root.
+			h.replaceTop(ranked[E]{e, seq})
+		}
+		seq++
+	}
+	if h.len() == 0 {
+		return nil
+	}
+	out := make([]E, h.len())
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i] = h.pop().e
+	}
+	return out
+}
@@ -0,0 +1,93 @@
+package xiter
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestTopK(t *testing.T) {
+	data := seqOf(3, 1, 4, 1, 5, 9, 2, 6)
+	if got := TopK(data, 3, cmp.Compare[int]); !reflect.DeepEqual(got, []int{9, 6, 5}) {
+		t.Fatalf("TopK got %v, want [9 6 5]", got)
+	}
+	if got := BottomK(data, 3, cmp.Compare[int]); !reflect.DeepEqual(got, []int{1, 1, 2}) {
+		t.Fatalf("BottomK got %v, want [1 1 2]", got)
+	}
+	if got := TopK(seqOf(2, 1), 5, cmp.Compare[int]); !reflect.DeepEqual(got, []int{2, 1}) {
+		t.Fatalf("k > n got %v, want [2 1]", got)
+	}
+	if got := TopK(data, 0, cmp.Compare[int]); got != nil {
+		t.Fatalf("k = 0 got %v, want nil", got)
+	}
+	if got := BottomK(Empty[int](), 3, cmp.Compare[int]); got != nil {
+		t.Fatalf("empty got %v, want nil", got)
+	}
+}
+
+func TestTopKStable(t *testing.T) {
+	type hit struct {
+		path  string
+		count int
+	}
+	hits := seqOf(hit{"a", 5}, hit{"b", 7}, hit{"c", 5}, hit{"d", 7}, hit{"e", 5})
+	byCount := func(x, y hit) int { return cmp.Compare(x.count, y.count) }
+	paths := func(hs []hit) []string {
+		return ToSlice(Map(seqOf(hs...), func(h hit) string { return h.path }))
+	}
+	if got := paths(TopK(hits, 3, byCount)); !reflect.DeepEqual(got, []string{"b", "d", "a"}) {
+		t.Fatalf("TopK got %v, want [b d a]", got)
+	}
+	if got := paths(BottomK(hits, 2, byCount)); !reflect.DeepEqual(got, []string{"a", "c"}) {
+		t.Fatalf("BottomK got %v, want [a c]", got)
+	}
+	count := func(h hit) int { return h.count }
+	if got := paths(TopKBy(hits, 4, count)); !reflect.DeepEqual(got, []string{"b", "d", "a", "c"}) {
+		t.Fatalf("TopKBy got %v, want [b d a c]", got)
+	}
+	if got := paths(BottomKBy(hits, 4, count)); !reflect.DeepEqual(got, []string{"a", "c", "e", "b"}) {
+		t.Fatalf("BottomKBy got %v, want [a c e b]", got)
+	}
+}
+
+func TestTopKBy(t *testing.T) {
+	calls := 0
+	key := func(s string) int {
+		calls++
+		return len(s)
+	}
+	got := TopKBy(seqOf("go", "iter", "x", "seq", "stream"), 2, key)
+	if !reflect.DeepEqual(got, []string{"stream", "iter"}) {
+		t.Fatalf("got %v, want [stream iter]", got)
+	}
+	if calls != 5 {
+		t.Fatalf("key called %d times, want 5", calls)
+	}
+	if got := BottomKBy(Empty[string](), 2, key); got != nil {
+		t.Fatalf("empty got %v, want nil", got)
+	}
+}
+
+func TestTopKMatchesStableSort(t *testing.T) {
+	type item struct{ key, id int }
+	r := rand.New(rand.NewPCG(9, 10))
+	byKey := func(a, b item) int { return cmp.Compare(a.key, b.key) }
+	for range 100 {
+		items := make([]item, r.IntN(200))
+		for i := range items {
+			items[i] = item{r.IntN(20), i}
+		}
+		k := r.IntN(30)
+		want := slices.Clone(items)
+		slices.SortStableFunc(want, func(a, b item) int { return -byKey(a, b) })
+		want = want[:min(k, len(want))]
+		if len(want) == 0 {
+			want = nil
+		}
+		if got := TopK(slices.Values(items), k, byKey); !reflect.DeepEqual(got, want) {
+			t.Fatalf("k=%d got %v, want %v", k, got, want)
+		}
+	}
+}
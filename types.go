@@ -4,3 +4,23 @@ package xiter
 type integral interface {
 	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
 }
+
+// Number is a constraint for the integer and floating-point types accepted by
+// the numeric aggregations such as Sum and Mean.
+type Number interface {
+	integral | ~float32 | ~float64
+}
+
+// pair carries a key/value pair through code paths that only handle a single
+// value, such as channels or element-typed helpers.
+type pair[K, V any] struct {
+	k K
+	v V
+}
+
+// KeyValue carries a key/value pair where a single value is required, such as
+// the element type of the channels used by FromChan2 and ToChan2.
+type KeyValue[K, V any] struct {
+	Key   K
+	Value V
+}
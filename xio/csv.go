@@ -0,0 +1,343 @@
+package xio
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// CSVOption configures the csv.Reader behind ReadCSV and ReadCSVInto, e.g.
+//
+//	semicolons := func(r *csv.Reader) { r.Comma = ';' }
+//	ReadCSV(f, semicolons)
+type CSVOption func(*csv.Reader)
+
+// ReadCSV yields the records of r as parsed by encoding/csv. A malformed
+// record is yielded together with its *csv.ParseError, which carries the line
+// number, and reading continues with the next record; for a record with the
+// wrong number of fields, the record itself is yielded as well. An I/O error
+// is yielded as the final pair.
+//
+//	ReadCSV(strings.NewReader("a,b\n1,2\n"))
+//	// yields ([a b],nil), ([1 2],nil)
+func ReadCSV(r io.Reader, opts ...CSVOption) iter.Seq2[[]string, error] {
+	return func(yield func([]string, error) bool) {
+		cr := newCSVReader(r, opts)
+		for {
+			rec, err := cr.Read()
+			if err == io.EOF {
+				return
+			}
+			if !yield(rec, err) || (err != nil && !isParseError(err)) {
+				return
+			}
+		}
+	}
+}
+
+// ReadCSVInto reads a CSV file with a header row and yields one T per
+// record, where T is a struct type. Each exported field is filled from the
+// column whose header equals the field's `csv` tag, or its name when it has
+// no tag; a header matches a field name case-insensitively. Fields tagged
+// `csv:"-"`, fields without a matching column and columns without a matching
+// field are ignored.
+//
+// Fields may be strings, booleans, integers, floats, or implement
+// encoding.TextUnmarshaler. An empty cell leaves its field at the zero
+// value. A cell that cannot be converted is reported as a *FieldError
+// carrying its line number, yielded with the record's partially filled value,
+// and reading continues. Errors from ReadCSV are passed through the same way.
+//
+//	type row struct {
+//	    Name string `csv:"name"`
+//	    Age  int    `csv:"age"`
+//	}
+//	ReadCSVInto[row](strings.NewReader("name,age\nann,30\n"))
+//	// yields ({ann 30},nil)
+func ReadCSVInto[T any](r io.Reader, opts ...CSVOption) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		fields, err := csvFields(reflect.TypeFor[T](), false)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		cr := newCSVReader(r, opts)
+		header, err := cr.Read()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		// With ReuseRecord the reader overwrites the header on the next Read.
+		header = slices.Clone(header)
+		columns := matchColumns(header, fields)
+		for {
+			rec, err := cr.Read()
+			if err == io.EOF {
+				return
+			}
+			if err != nil && !isParseError(err) {
+				yield(zero, err)
+				return
+			}
+			var v T
+			if rec != nil {
+				if ferr := decodeRecord(cr, rec, header, columns, reflect.ValueOf(&v).Elem()); err == nil {
+					err = ferr
+				}
+			}
+			if !yield(v, err) {
+				return
+			}
+		}
+	}
+}
+
+// WriteCSV writes every element of s to w as a CSV record and returns the
+// first error, at which point s is no longer consumed. When T is []string,
+// each element is written as is. When T is a struct type, a header row is
+// written first, using the same field naming rules as ReadCSVInto, and each
+// field is formatted with encoding.TextMarshaler if it implements it and
+// strconv otherwise. Output is flushed before WriteCSV returns.
+//
+//	WriteCSV(os.Stdout, slices.Values([]row{{"ann", 30}}))
+//	// name,age
+//	// ann,30
+func WriteCSV[T any](w io.Writer, s iter.Seq[T]) error {
+	cw := csv.NewWriter(w)
+	if err := writeCSV(cw, s); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeCSV[T any](cw *csv.Writer, s iter.Seq[T]) error {
+	if rows, ok := any(s).(iter.Seq[[]string]); ok {
+		for rec := range rows {
+			if err := cw.Write(rec); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	fields, err := csvFields(reflect.TypeFor[T](), true)
+	if err != nil {
+		return err
+	}
+	rec := make([]string, len(fields))
+	for i, f := range fields {
+		rec[i] = f.name
+	}
+	if err := cw.Write(rec); err != nil {
+		return err
+	}
+	for v := range s {
+		rv := reflect.ValueOf(&v).Elem()
+		for i, f := range fields {
+			if rec[i], err = formatField(rv.Field(f.index)); err != nil {
+				return fmt.Errorf("xio: field %s: %w", f.goName, err)
+			}
+		}
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FieldError reports a CSV cell that could not be converted into the struct
+// field it maps to.
+type FieldError struct {
+	Line   int    // line of the cell in the input, starting at 1
+	Column string // header of the cell's column
+	Field  string // name of the struct field
+	Value  string // content of the cell
+	Err    error  // the conversion error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("xio: line %d, column %q: cannot set field %s from %q: %v", e.Line, e.Column, e.Field, e.Value, e.Err)
+}
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+func newCSVReader(r io.Reader, opts []CSVOption) *csv.Reader {
+	cr := csv.NewReader(r)
+	for _, opt := range opts {
+		opt(cr)
+	}
+	return cr
+}
+
+// isParseError reports whether err describes a single malformed record,
+// after which the csv.Reader can go on with the next one.
+func isParseError(err error) bool {
+	var perr *csv.ParseError
+	return errors.As(err, &perr)
+}
+
+// csvField maps a struct field to a CSV column name.
+type csvField struct {
+	index  int
+	name   string
+	goName string
+}
+
+var (
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// csvFields lists the exported fields of struct type t that take part in CSV
+// mapping, rejecting field types that cannot be parsed from or, when write is
+// set, formatted to a cell.
+func csvFields(t reflect.Type, write bool) ([]csvField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("xio: cannot map CSV records to %v: not a struct", t)
+	}
+	var fields []csvField
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(sf.Tag.Get("csv"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		if !csvConvertible(sf.Type, write) {
+			return nil, fmt.Errorf("xio: cannot map CSV column to field %s of type %v", sf.Name, sf.Type)
+		}
+		fields = append(fields, csvField{index: i, name: name, goName: sf.Name})
+	}
+	return fields, nil
+}
+
+func csvConvertible(t reflect.Type, write bool) bool {
+	text := textUnmarshalerType
+	if write {
+		text = textMarshalerType
+	}
+	if reflect.PointerTo(t).Implements(text) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// matchColumns returns, for every header column, the field it fills or nil.
+func matchColumns(header []string, fields []csvField) []*csvField {
+	columns := make([]*csvField, len(header))
+	for i, h := range header {
+		for j := range fields {
+			if fields[j].name == h {
+				columns[i] = &fields[j]
+				break
+			}
+		}
+		if columns[i] != nil {
+			continue
+		}
+		for j := range fields {
+			if strings.EqualFold(fields[j].name, h) {
+				columns[i] = &fields[j]
+				break
+			}
+		}
+	}
+	return columns
+}
+
+// decodeRecord fills v from rec and returns the first conversion error.
+func decodeRecord(cr *csv.Reader, rec, header []string, columns []*csvField, v reflect.Value) error {
+	var first error
+	for i, cell := range rec {
+		if i >= len(columns) || columns[i] == nil {
+			continue
+		}
+		f := columns[i]
+		if err := parseField(v.Field(f.index), cell); err != nil && first == nil {
+			line, _ := cr.FieldPos(i)
+			first = &FieldError{Line: line, Column: header[i], Field: f.goName, Value: cell, Err: err}
+		}
+	}
+	return first
+}
+
+func parseField(fv reflect.Value, s string) error {
+	if s == "" {
+		return nil
+	}
+	if u, ok := fv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(n)
+	}
+	return nil
+}
+
+func formatField(fv reflect.Value) (string, error) {
+	if m, ok := fv.Addr().Interface().(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		return fv.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(fv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(fv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(fv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(fv.Float(), 'g', -1, fv.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("unsupported type %v", fv.Type())
+}
@@ -0,0 +1,192 @@
+package xio
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadCSV(t *testing.T) {
+	vals, errs := collect(ReadCSV(strings.NewReader("a,b\n1,\"x,y\"\n")))
+	if want := [][]string{{"a", "b"}, {"1", "x,y"}}; !reflect.DeepEqual(vals, want) {
+		t.Fatalf("got %q, want %q", vals, want)
+	}
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("got error %v", err)
+		}
+	}
+
+	semicolons := func(r *csv.Reader) { r.Comma = ';' }
+	vals, _ = collect(ReadCSV(strings.NewReader("a;b\n"), semicolons))
+	if !reflect.DeepEqual(vals, [][]string{{"a", "b"}}) {
+		t.Fatalf("got %q", vals)
+	}
+
+	// A malformed row is reported with its line and reading goes on.
+	vals, errs = collect(ReadCSV(strings.NewReader("a,b\n1\n2,3\n")))
+	var perr *csv.ParseError
+	if len(vals) != 3 || !errors.As(errs[1], &perr) || perr.Line != 2 || errs[2] != nil {
+		t.Fatalf("got %q %v", vals, errs)
+	}
+	if !reflect.DeepEqual(vals[1], []string{"1"}) || !reflect.DeepEqual(vals[2], []string{"2", "3"}) {
+		t.Fatalf("got %q", vals)
+	}
+
+	_, errs = collect(ReadCSV(failingReader("a,b\n")))
+	if !errors.Is(errs[len(errs)-1], errRead) {
+		t.Fatalf("got errors %v, want %v", errs, errRead)
+	}
+	for range ReadCSV(strings.NewReader("a\nb\n")) {
+		break
+	}
+}
+
+type level int
+
+func (l *level) UnmarshalText(b []byte) error {
+	switch string(b) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return errors.New("unknown level")
+	}
+	return nil
+}
+
+func (l level) MarshalText() ([]byte, error) {
+	return []byte([]string{"", "low", "high"}[l]), nil
+}
+
+type record struct {
+	Name    string    `csv:"name"`
+	Age     int       `csv:"age"`
+	Score   float64   `csv:"score"`
+	Active  bool      // matched by field name
+	Level   level     `csv:"level"`
+	Since   time.Time `csv:"since"`
+	Ignored string    `csv:"-"`
+	private int
+}
+
+func TestReadCSVInto(t *testing.T) {
+	input := "NAME,active,age,extra,score,level,since\n" +
+		"ann,true,30,x,1.5,high,2024-01-02T00:00:00Z\n" +
+		"bob,,,,,low,\n"
+	vals, errs := collect(ReadCSVInto[record](strings.NewReader(input)))
+	since := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	want := []record{
+		{Name: "ann", Age: 30, Score: 1.5, Active: true, Level: 2, Since: since},
+		{Name: "bob", Level: 1},
+	}
+	if !reflect.DeepEqual(vals, want) {
+		t.Fatalf("got %+v, want %+v", vals, want)
+	}
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("got error %v", err)
+		}
+	}
+	if vals, errs := collect(ReadCSVInto[record](strings.NewReader(""))); len(vals) != 0 || len(errs) != 0 {
+		t.Fatalf("empty input got %v %v", vals, errs)
+	}
+}
+
+func TestReadCSVIntoErrors(t *testing.T) {
+	input := "name,age,level\nann,30,low\nbob,old,low\ncy,40,odd\ndee,50\n"
+	vals, errs := collect(ReadCSVInto[record](strings.NewReader(input)))
+	if len(vals) != 4 || errs[0] != nil {
+		t.Fatalf("got %+v %v", vals, errs)
+	}
+
+	var ferr *FieldError
+	if !errors.As(errs[1], &ferr) || ferr.Line != 3 || ferr.Column != "age" || ferr.Field != "Age" || ferr.Value != "old" {
+		t.Fatalf("got error %#v", errs[1])
+	}
+	if !errors.Is(errs[1], strconv.ErrSyntax) || !strings.Contains(errs[1].Error(), "line 3") {
+		t.Fatalf("got error %v", errs[1])
+	}
+	if vals[1].Name != "bob" || vals[1].Level != 1 {
+		t.Fatalf("partial record got %+v", vals[1])
+	}
+	if !errors.As(errs[2], &ferr) || ferr.Line != 4 || ferr.Column != "level" {
+		t.Fatalf("got error %v", errs[2])
+	}
+	var perr *csv.ParseError
+	if !errors.As(errs[3], &perr) || perr.Line != 5 || vals[3].Name != "dee" || vals[3].Age != 50 {
+		t.Fatalf("got %+v, error %v", vals[3], errs[3])
+	}
+
+	_, errs = collect(ReadCSVInto[int](strings.NewReader("a\n1\n")))
+	if len(errs) != 1 || errs[0] == nil {
+		t.Fatalf("non-struct type got errors %v", errs)
+	}
+	type bad struct{ C chan int }
+	if _, errs = collect(ReadCSVInto[bad](strings.NewReader("C\n1\n"))); len(errs) != 1 || errs[0] == nil {
+		t.Fatalf("unsupported field got errors %v", errs)
+	}
+}
+
+func TestReadCSVIntoReuseRecord(t *testing.T) {
+	reuse := func(r *csv.Reader) { r.ReuseRecord = true }
+	input := "name,age\nann,30\nbob,old\n"
+	vals, errs := collect(ReadCSVInto[record](strings.NewReader(input), reuse))
+	if len(vals) != 2 || vals[0].Name != "ann" || vals[0].Age != 30 || vals[1].Name != "bob" || errs[0] != nil {
+		t.Fatalf("got %+v %v", vals, errs)
+	}
+	var ferr *FieldError
+	if !errors.As(errs[1], &ferr) || ferr.Column != "age" {
+		t.Fatalf("got error %v, want a FieldError for column age", errs[1])
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	rows := []record{
+		{Name: "ann", Age: 30, Score: 1.5, Active: true, Level: 2, Since: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{Name: "b,c", Level: 1},
+	}
+	if err := WriteCSV(&buf, slices.Values(rows)); err != nil {
+		t.Fatal(err)
+	}
+	want := "name,age,score,Active,level,since\n" +
+		"ann,30,1.5,true,high,2024-01-02T00:00:00Z\n" +
+		"\"b,c\",0,0,false,low,0001-01-01T00:00:00Z\n"
+	if buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+	back, _ := collect(ReadCSVInto[record](&buf))
+	if !reflect.DeepEqual(back, rows) {
+		t.Fatalf("round trip got %+v, want %+v", back, rows)
+	}
+
+	buf.Reset()
+	if err := WriteCSV(&buf, slices.Values([][]string{{"a", "b"}, {"1", "2"}})); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "a,b\n1,2\n" {
+		t.Fatalf("got %q", buf.String())
+	}
+
+	if err := WriteCSV(&buf, slices.Values([]int{1})); err == nil {
+		t.Fatal("writing ints succeeded")
+	}
+	src := func(yield func([]string) bool) {
+		for {
+			if !yield([]string{strings.Repeat("x", 4096)}) {
+				return
+			}
+		}
+	}
+	if err := WriteCSV(&failingWriter{}, src); !errors.Is(err, errRead) {
+		t.Fatalf("got %v, want %v", err, errRead)
+	}
+}
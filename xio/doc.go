@@ -0,0 +1,23 @@
+// Package xio adapts byte streams to the sequences of package xiter: it
+// reads lines, runes, chunks, JSON values and CSV records from an io.Reader
+// as sequences, and writes sequences back out to an io.Writer.
+//
+// Every source in this package reads from an io.Reader and yields
+// error-carrying pairs: each element arrives as (value, nil), and a read
+// error other than io.EOF is yielded once as a final (zero, err) pair before
+// the sequence ends. The results therefore plug directly into the
+// error-aware helpers of xiter, such as MapErr, FilterErr, CollectErr and
+// TryForEach2:
+//
+//	err := xiter.TryForEach2(xio.Lines(r), func(line string, err error) error {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    return handle(line)
+//	})
+//
+// The sequences read from r as they are iterated and are therefore
+// single-use: iterating one again continues from wherever the reader was
+// left, and data buffered by an earlier iteration is lost. Breaking out of a
+// loop early leaves r open; closing it remains the caller's responsibility.
+package xio
@@ -0,0 +1,184 @@
+package xio_test
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-board/xiter"
+	"github.com/go-board/xiter/xio"
+)
+
+func ExampleLines() {
+	r := strings.NewReader("alpha\n# comment\nbeta\n")
+	lines := xiter.FilterErr(xio.Lines(r), func(l string) bool {
+		return !strings.HasPrefix(l, "#")
+	})
+	got, err := xiter.CollectErr(lines)
+	fmt.Println(got, err)
+	// Output:
+	// [alpha beta] <nil>
+}
+
+func ExampleLines_maxTokenSize() {
+	r := strings.NewReader("ok\n" + strings.Repeat("x", 64) + "\n")
+	err := xiter.TryForEach2(xio.Lines(r, xio.MaxTokenSize(32)), func(line string, err error) error {
+		if err != nil {
+			return err
+		}
+		fmt.Println(line)
+		return nil
+	})
+	fmt.Println(err)
+	// Output:
+	// ok
+	// bufio.Scanner: token too long
+}
+
+func ExampleScanWith() {
+	words := xio.ScanWith(strings.NewReader("the quick  brown fox"), bufio.ScanWords)
+	for w, err := range words {
+		if err != nil {
+			break
+		}
+		fmt.Println(w)
+	}
+	// Output:
+	// the
+	// quick
+	// brown
+	// fox
+}
+
+func ExampleRunes() {
+	n := 0
+	for r, err := range xio.Runes(strings.NewReader("héllo, 世界")) {
+		if err != nil {
+			break
+		}
+		if r > 127 {
+			n++
+		}
+	}
+	fmt.Println("non-ASCII runes:", n)
+	// Output:
+	// non-ASCII runes: 3
+}
+
+func ExampleBytes() {
+	for chunk, err := range xio.Bytes(strings.NewReader("abcdefg"), 3) {
+		if err != nil {
+			break
+		}
+		fmt.Printf("%s\n", chunk)
+	}
+	// Output:
+	// abc
+	// def
+	// g
+}
+
+func ExampleDecodeJSONArray() {
+	type user struct {
+		Name   string `json:"name"`
+		Active bool   `json:"active"`
+	}
+	r := strings.NewReader(`[{"name":"ann","active":true},{"name":"bob"},{"name":"cy","active":true}]`)
+	users := xio.DecodeJSONArray[user](r)
+	active := xiter.FilterErr(users, func(u user) bool { return u.Active })
+	names := xiter.MapErr(active, func(u user) (string, error) { return u.Name, nil })
+	got, err := xiter.CollectErr(names)
+	fmt.Println(got, err)
+	// Output:
+	// [ann cy] <nil>
+}
+
+func ExampleDecodeNDJSON() {
+	r := strings.NewReader("{\"n\":1}\n{\"n\":2}\n")
+	for v, err := range xio.DecodeNDJSON[map[string]int](r) {
+		if err != nil {
+			break
+		}
+		fmt.Println(v["n"])
+	}
+	// Output:
+	// 1
+	// 2
+}
+
+func ExampleEncodeNDJSON() {
+	squares := xiter.Map(xiter.Range1(3), func(n int) map[string]int {
+		return map[string]int{"n": n, "sq": n * n}
+	})
+	if err := xio.EncodeNDJSON(os.Stdout, squares); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// {"n":0,"sq":0}
+	// {"n":1,"sq":1}
+	// {"n":2,"sq":4}
+}
+
+func ExampleEncodeJSONArray() {
+	if err := xio.EncodeJSONArray(os.Stdout, xiter.Range1(4)); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println()
+	// Output:
+	// [0,1,2,3]
+}
+
+func ExampleReadCSV() {
+	r := strings.NewReader("id,name\n1,ann\n2\n3,cy\n")
+	for rec, err := range xio.ReadCSV(r) {
+		if err != nil {
+			fmt.Println("skipping:", err)
+			continue
+		}
+		fmt.Println(rec)
+	}
+	// Output:
+	// [id name]
+	// [1 ann]
+	// skipping: record on line 3: wrong number of fields
+	// [3 cy]
+}
+
+func ExampleReadCSVInto() {
+	type order struct {
+		Customer string  `csv:"customer"`
+		Amount   float64 `csv:"amount"`
+	}
+	r := strings.NewReader("customer,amount\nann,10.5\nbob,oops\nann,4.5\n")
+	total := map[string]float64{}
+	for o, err := range xio.ReadCSVInto[order](r) {
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		total[o.Customer] += o.Amount
+	}
+	fmt.Println(total)
+	// Output:
+	// xio: line 3, column "amount": cannot set field Amount from "oops": strconv.ParseFloat: parsing "oops": invalid syntax
+	// map[ann:15]
+}
+
+func ExampleWriteCSV() {
+	type total struct {
+		Customer string  `csv:"customer"`
+		Amount   float64 `csv:"amount"`
+	}
+	rows := xiter.Map(xiter.Range1(3), func(i int) total {
+		return total{Customer: string(rune('a' + i)), Amount: float64(i) * 1.5}
+	})
+	if err := xio.WriteCSV(os.Stdout, rows); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// customer,amount
+	// a,0
+	// b,1.5
+	// c,3
+}
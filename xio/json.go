@@ -0,0 +1,124 @@
+package xio
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// DecodeJSONArray decodes the elements of a top-level JSON array in r one at
+// a time, so arrays far larger than memory can be processed as a sequence.
+// Only the current element is held in memory, and nothing past it is read
+// until the consumer asks for the next one.
+//
+// An element that is well-formed JSON but does not fit T is yielded as
+// (partially decoded value, *json.UnmarshalTypeError) and decoding continues
+// with the next element. Any other error, including input that is not a
+// JSON array, is yielded as the final pair.
+//
+//	DecodeJSONArray[int](strings.NewReader("[1, 2, 3]"))
+//	// yields (1,nil), (2,nil), (3,nil)
+func DecodeJSONArray[T any](r io.Reader) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		dec := json.NewDecoder(r)
+		tok, err := dec.Token()
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		if tok != json.Delim('[') {
+			yield(zero, fmt.Errorf("xio: expected JSON array, found %v", tok))
+			return
+		}
+		for dec.More() {
+			var v T
+			err := dec.Decode(&v)
+			if !yield(v, err) || fatalDecode(err) {
+				return
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// DecodeNDJSON decodes newline-delimited JSON from r, yielding one value per
+// line. Blank lines are skipped. As with DecodeJSONArray, a value that does
+// not fit T is yielded with its *json.UnmarshalTypeError and decoding
+// continues, while any other error ends the sequence.
+//
+// The one-value-per-line layout is not enforced: like json.Decoder, it
+// accepts any whitespace between values, so concatenated JSON such as
+// {"a":1} {"a":2} on a single line, or a value spanning several lines, is
+// decoded as well.
+//
+//	DecodeNDJSON[map[string]int](strings.NewReader("{\"a\":1}\n{\"a\":2}\n"))
+//	// yields (map[a:1],nil), (map[a:2],nil)
+func DecodeNDJSON[T any](r io.Reader) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		dec := json.NewDecoder(r)
+		for {
+			var v T
+			err := dec.Decode(&v)
+			if err == io.EOF {
+				return
+			}
+			if !yield(v, err) || fatalDecode(err) {
+				return
+			}
+		}
+	}
+}
+
+// fatalDecode reports whether err, returned by json.Decoder.Decode, leaves
+// the decoder unable to continue. A type mismatch only affects the value
+// being decoded; the decoder has still consumed it.
+func fatalDecode(err error) bool {
+	var typeErr *json.UnmarshalTypeError
+	return err != nil && !errors.As(err, &typeErr)
+}
+
+// EncodeNDJSON writes every element of s to w as one line of JSON and
+// returns the first encoding or write error, at which point s is no longer
+// consumed. Each element is written as soon as it is produced; wrap w in a
+// bufio.Writer to batch small writes.
+func EncodeNDJSON[T any](w io.Writer, s iter.Seq[T]) error {
+	enc := json.NewEncoder(w)
+	for v := range s {
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EncodeJSONArray writes the elements of s to w as a single JSON array and
+// returns the first encoding or write error, at which point s is no longer
+// consumed and the array is left unterminated. Each element is written as
+// soon as it is produced, so the whole array is never held in memory; wrap w
+// in a bufio.Writer to batch small writes.
+func EncodeJSONArray[T any](w io.Writer, s iter.Seq[T]) error {
+	sep := byte('[')
+	var buf []byte
+	for v := range s {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf[:0], sep), data...)
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+		sep = ','
+	}
+	end := "]"
+	if sep == '[' {
+		end = "[]"
+	}
+	_, err := io.WriteString(w, end)
+	return err
+}
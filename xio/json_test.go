@@ -0,0 +1,161 @@
+package xio
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type point struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+func TestDecodeJSONArray(t *testing.T) {
+	vals, errs := collect(DecodeJSONArray[point](strings.NewReader(`[{"x":1,"y":2}, {"x":3}]`)))
+	if want := []point{{1, 2}, {3, 0}}; !reflect.DeepEqual(vals, want) {
+		t.Fatalf("got %v, want %v", vals, want)
+	}
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("got error %v", err)
+		}
+	}
+	if vals, errs := collect(DecodeJSONArray[int](strings.NewReader(" [ ] "))); len(vals) != 0 || len(errs) != 0 {
+		t.Fatalf("got %v %v, want empty", vals, errs)
+	}
+}
+
+func TestDecodeJSONArrayErrors(t *testing.T) {
+	// A type mismatch is reported for that element only.
+	vals, errs := collect(DecodeJSONArray[int](strings.NewReader(`[1, "two", 3]`)))
+	var typeErr *json.UnmarshalTypeError
+	if !reflect.DeepEqual(vals, []int{1, 0, 3}) || errs[0] != nil || !errors.As(errs[1], &typeErr) || errs[2] != nil {
+		t.Fatalf("got %v %v", vals, errs)
+	}
+
+	// Malformed input ends the sequence.
+	vals, errs = collect(DecodeJSONArray[int](strings.NewReader(`[1, 2 3]`)))
+	if len(vals) != 3 || errs[0] != nil || errs[1] != nil || errs[2] == nil {
+		t.Fatalf("got %v %v", vals, errs)
+	}
+	_, errs = collect(DecodeJSONArray[int](strings.NewReader(`[1, 2`)))
+	if errs[len(errs)-1] == nil {
+		t.Fatalf("truncated array got errors %v", errs)
+	}
+	_, errs = collect(DecodeJSONArray[int](strings.NewReader(`{"a": 1}`)))
+	if len(errs) != 1 || errs[0] == nil {
+		t.Fatalf("object input got errors %v", errs)
+	}
+	_, errs = collect(DecodeJSONArray[int](failingReader(`[1,`)))
+	if !errors.Is(errs[len(errs)-1], errRead) {
+		t.Fatalf("got errors %v, want %v", errs, errRead)
+	}
+}
+
+func TestDecodeJSONArrayEarlyStop(t *testing.T) {
+	// The reader fails after the first element; stopping after it must not
+	// read far enough to observe the failure.
+	for v, err := range DecodeJSONArray[int](failingReader(`[1, 2, 3`)) {
+		if err != nil || v != 1 {
+			t.Fatalf("got (%v, %v), want (1, nil)", v, err)
+		}
+		break
+	}
+}
+
+func TestDecodeNDJSON(t *testing.T) {
+	input := "{\"x\":1,\"y\":2}\n\n{\"x\":3,\"y\":4}\n"
+	vals, errs := collect(DecodeNDJSON[point](strings.NewReader(input)))
+	if want := []point{{1, 2}, {3, 4}}; !reflect.DeepEqual(vals, want) {
+		t.Fatalf("got %v, want %v", vals, want)
+	}
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("got error %v", err)
+		}
+	}
+
+	vals, errs = collect(DecodeNDJSON[point](strings.NewReader("{\"x\":\"a\"}\n{\"x\":2}\n{oops\n{\"x\":3}\n")))
+	if len(vals) != 3 || errs[0] == nil || errs[1] != nil || vals[1].X != 2 || errs[2] == nil {
+		t.Fatalf("got %v %v", vals, errs)
+	}
+	vals, errs = collect(DecodeNDJSON[point](strings.NewReader("{\"x\":1} {\"x\":2}\n{\n\"x\":3}\n")))
+	if want := []point{{X: 1}, {X: 2}, {X: 3}}; !reflect.DeepEqual(vals, want) || slices.ContainsFunc(errs, func(err error) bool { return err != nil }) {
+		t.Fatalf("concatenated JSON got %v %v", vals, errs)
+	}
+	for range DecodeNDJSON[int](strings.NewReader("1\n2\n")) {
+		break
+	}
+}
+
+// failingWriter accepts n writes and fails every later one.
+type failingWriter struct{ n int }
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.n == 0 {
+		return 0, errRead
+	}
+	w.n--
+	return len(p), nil
+}
+
+func TestEncodeNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeNDJSON(&buf, slices.Values([]point{{1, 2}, {3, 4}})); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "{\"x\":1,\"y\":2}\n{\"x\":3,\"y\":4}\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	vals, _ := collect(DecodeNDJSON[point](&buf))
+	if !reflect.DeepEqual(vals, []point{{1, 2}, {3, 4}}) {
+		t.Fatalf("round trip got %v", vals)
+	}
+
+	pulled := 0
+	src := func(yield func(int) bool) {
+		for i := range 5 {
+			pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	if err := EncodeNDJSON(&failingWriter{n: 1}, src); !errors.Is(err, errRead) || pulled != 2 {
+		t.Fatalf("got %v after pulling %d, want %v after 2", err, pulled, errRead)
+	}
+	if err := EncodeNDJSON(&buf, slices.Values([]any{func() {}})); err == nil {
+		t.Fatal("encoding a func succeeded")
+	}
+}
+
+func TestEncodeJSONArray(t *testing.T) {
+	for _, tt := range []struct {
+		in   []int
+		want string
+	}{
+		{nil, "[]"},
+		{[]int{1}, "[1]"},
+		{[]int{1, 2, 3}, "[1,2,3]"},
+	} {
+		var buf bytes.Buffer
+		if err := EncodeJSONArray(&buf, slices.Values(tt.in)); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tt.want {
+			t.Fatalf("got %q, want %q", buf.String(), tt.want)
+		}
+		var back []int
+		if err := json.Unmarshal(buf.Bytes(), &back); err != nil || len(back) != len(tt.in) {
+			t.Fatalf("output %q does not round trip: %v", buf.String(), err)
+		}
+	}
+	if err := EncodeJSONArray(&failingWriter{n: 1}, slices.Values([]int{1, 2})); !errors.Is(err, errRead) {
+		t.Fatalf("got %v, want %v", err, errRead)
+	}
+}
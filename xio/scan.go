@@ -0,0 +1,118 @@
+package xio
+
+import (
+	"bufio"
+	"io"
+	"iter"
+)
+
+// ScanOption configures the bufio.Scanner behind Lines and ScanWith.
+type ScanOption func(*bufio.Scanner)
+
+// MaxTokenSize sets the largest token, such as a line, the scanner accepts.
+// The default is bufio.MaxScanTokenSize (64 KiB). A longer token stops the
+// sequence with bufio.ErrTooLong as its final error. When n <= 0 the default
+// is kept.
+func MaxTokenSize(n int) ScanOption {
+	return func(sc *bufio.Scanner) {
+		if n <= 0 {
+			return
+		}
+		size := min(n, 4096)
+		sc.Buffer(make([]byte, 0, size), n)
+	}
+}
+
+// Lines yields the lines of r without their line endings, as split by
+// bufio.ScanLines: a trailing "\r" is dropped and a final line without a
+// newline is still yielded. A read error is yielded as the final pair.
+//
+//	Lines(strings.NewReader("a\nb\r\nc"))  // yields ("a",nil), ("b",nil), ("c",nil)
+func Lines(r io.Reader, opts ...ScanOption) iter.Seq2[string, error] {
+	return ScanWith(r, bufio.ScanLines, opts...)
+}
+
+// ScanWith yields the tokens of r as split by split, the same way a
+// bufio.Scanner using that split function would. Each token is copied into a
+// string, so it stays valid after the next iteration step. A read or split
+// error is yielded as the final pair.
+//
+//	ScanWith(strings.NewReader("a b  c"), bufio.ScanWords)
+//	// yields ("a",nil), ("b",nil), ("c",nil)
+func ScanWith(r io.Reader, split bufio.SplitFunc, opts ...ScanOption) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		sc := bufio.NewScanner(r)
+		sc.Split(split)
+		for _, opt := range opts {
+			opt(sc)
+		}
+		for sc.Scan() {
+			if !yield(sc.Text(), nil) {
+				return
+			}
+		}
+		if err := sc.Err(); err != nil {
+			yield("", err)
+		}
+	}
+}
+
+// Runes yields the UTF-8 encoded runes of r. Invalid encodings are yielded
+// as utf8.RuneError, one byte at a time, as bufio.Reader.ReadRune does. A
+// read error is yielded as the final pair.
+//
+//	Runes(strings.NewReader("hé"))  // yields ('h',nil), ('é',nil)
+func Runes(r io.Reader) iter.Seq2[rune, error] {
+	return func(yield func(rune, error) bool) {
+		br, ok := r.(io.RuneReader)
+		if !ok {
+			br = bufio.NewReader(r)
+		}
+		for {
+			c, _, err := br.ReadRune()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(0, err)
+				return
+			}
+			if !yield(c, nil) {
+				return
+			}
+		}
+	}
+}
+
+// defaultChunkSize is the chunk size Bytes uses when none is given.
+const defaultChunkSize = 32 * 1024
+
+// Bytes yields the contents of r in chunks of chunkSize bytes; only the last
+// chunk may be shorter. When chunkSize <= 0, chunks of 32 KiB are used. Each
+// chunk is a freshly allocated slice that the consumer may retain. A read
+// error is yielded as the final pair, after any bytes read before it.
+//
+//	Bytes(strings.NewReader("abcde"), 2)  // yields ("ab",nil), ("cd",nil), ("e",nil)
+func Bytes(r io.Reader, chunkSize int) iter.Seq2[[]byte, error] {
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+	return func(yield func([]byte, error) bool) {
+		for {
+			buf := make([]byte, chunkSize)
+			n, err := io.ReadFull(r, buf)
+			if n > 0 && !yield(buf[:n], nil) {
+				return
+			}
+			switch err {
+			case nil:
+				continue
+			case io.EOF, io.ErrUnexpectedEOF:
+				return
+			default:
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package xio
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"iter"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var errRead = errors.New("read failed")
+
+// collect drains s, returning the yielded values and the errors paired with
+// them.
+func collect[E any](s iter.Seq2[E, error]) ([]E, []error) {
+	var vals []E
+	var errs []error
+	for v, err := range s {
+		vals = append(vals, v)
+		errs = append(errs, err)
+	}
+	return vals, errs
+}
+
+// failingReader returns a reader that yields s and then fails with errRead.
+func failingReader(s string) io.Reader {
+	return io.MultiReader(strings.NewReader(s), iotest.ErrReader(errRead))
+}
+
+func TestLines(t *testing.T) {
+	vals, errs := collect(Lines(strings.NewReader("a\nb\r\n\nc")))
+	if want := []string{"a", "b", "", "c"}; !reflect.DeepEqual(vals, want) {
+		t.Fatalf("got %q, want %q", vals, want)
+	}
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("got error %v", err)
+		}
+	}
+	if vals, _ := collect(Lines(strings.NewReader(""))); len(vals) != 0 {
+		t.Fatalf("got %q, want empty", vals)
+	}
+
+	vals, errs = collect(Lines(failingReader("a\nb\n")))
+	if want := []string{"a", "b", ""}; !reflect.DeepEqual(vals, want) {
+		t.Fatalf("got %q, want %q", vals, want)
+	}
+	if errs[0] != nil || errs[1] != nil || !errors.Is(errs[2], errRead) {
+		t.Fatalf("got errors %v", errs)
+	}
+	for range Lines(strings.NewReader("a\nb")) {
+		break
+	}
+}
+
+func TestLinesMaxTokenSize(t *testing.T) {
+	input := "short\n" + strings.Repeat("x", 100) + "\nnext\n"
+	vals, errs := collect(Lines(strings.NewReader(input), MaxTokenSize(16)))
+	if !reflect.DeepEqual(vals, []string{"short", ""}) || !errors.Is(errs[1], bufio.ErrTooLong) {
+		t.Fatalf("got %q %v", vals, errs)
+	}
+
+	long := strings.Repeat("y", 100*1024)
+	vals, errs = collect(Lines(strings.NewReader(long), MaxTokenSize(200*1024)))
+	if len(vals) != 1 || vals[0] != long || errs[0] != nil {
+		t.Fatalf("got %d lines, errors %v", len(vals), errs)
+	}
+	if _, errs := collect(Lines(strings.NewReader(long))); !errors.Is(errs[len(errs)-1], bufio.ErrTooLong) {
+		t.Fatalf("default limit got errors %v", errs)
+	}
+}
+
+func TestScanWith(t *testing.T) {
+	vals, _ := collect(ScanWith(strings.NewReader(" a  bb\tc\n"), bufio.ScanWords))
+	if want := []string{"a", "bb", "c"}; !reflect.DeepEqual(vals, want) {
+		t.Fatalf("got %q, want %q", vals, want)
+	}
+	// Tokens are copies, so they stay valid while the scanner refills its
+	// buffer from a reader that returns one byte at a time.
+	vals, _ = collect(ScanWith(iotest.OneByteReader(strings.NewReader("ab cd ef")), bufio.ScanWords))
+	if want := []string{"ab", "cd", "ef"}; !reflect.DeepEqual(vals, want) {
+		t.Fatalf("got %q, want %q", vals, want)
+	}
+}
+
+func TestRunes(t *testing.T) {
+	vals, errs := collect(Runes(iotest.HalfReader(strings.NewReader("hé世\xff"))))
+	if want := []rune{'h', 'é', '世', '�'}; !reflect.DeepEqual(vals, want) {
+		t.Fatalf("got %q, want %q", vals, want)
+	}
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("got error %v", err)
+		}
+	}
+
+	vals, errs = collect(Runes(failingReader("ab")))
+	if !reflect.DeepEqual(vals, []rune{'a', 'b', 0}) || !errors.Is(errs[2], errRead) {
+		t.Fatalf("got %q %v", vals, errs)
+	}
+	for range Runes(strings.NewReader("ab")) {
+		break
+	}
+}
+
+func TestBytes(t *testing.T) {
+	vals, errs := collect(Bytes(iotest.OneByteReader(strings.NewReader("abcde")), 2))
+	want := [][]byte{[]byte("ab"), []byte("cd"), []byte("e")}
+	if !reflect.DeepEqual(vals, want) {
+		t.Fatalf("got %q, want %q", vals, want)
+	}
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("got error %v", err)
+		}
+	}
+	if vals, _ := collect(Bytes(strings.NewReader("abcd"), 2)); len(vals) != 2 {
+		t.Fatalf("got %q, want 2 chunks", vals)
+	}
+	if vals, _ := collect(Bytes(strings.NewReader("abc"), 0)); !reflect.DeepEqual(vals, [][]byte{[]byte("abc")}) {
+		t.Fatalf("default chunk size got %q", vals)
+	}
+
+	vals, errs = collect(Bytes(failingReader("abc"), 2))
+	if len(vals) != 3 || string(vals[0]) != "ab" || string(vals[1]) != "c" || !errors.Is(errs[2], errRead) {
+		t.Fatalf("got %q %v", vals, errs)
+	}
+	for range Bytes(strings.NewReader("abcd"), 1) {
+		break
+	}
+}
@@ -0,0 +1,17 @@
+// Package xsql adapts database/sql result sets, and any other cursor-style
+// API, to the sequences of package xiter.
+//
+// Each row arrives as an error-carrying pair: (value, nil) for a row that
+// scanned successfully, (zero or partial value, err) for one that did not.
+// An error that ends the result set, such as rows.Err, is yielded once as the
+// final pair. The underlying cursor is always closed: when it is exhausted,
+// and when the consumer stops early, e.g. through xiter.Take or
+// xiter.FirstFunc, so an abandoned loop cannot leak a connection.
+//
+//	users := xsql.FromRows(rows, func(r *sql.Rows) (User, error) {
+//	    var u User
+//	    err := r.Scan(&u.ID, &u.Name)
+//	    return u, err
+//	})
+//	first10, err := xiter.CollectErr(xiter.Take2(users, 10))
+package xsql
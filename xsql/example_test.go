@@ -0,0 +1,46 @@
+package xsql_test
+
+import (
+	"fmt"
+
+	"github.com/go-board/xiter"
+	"github.com/go-board/xiter/xsql"
+)
+
+// pager is a stand-in for a legacy client library that exposes results
+// through a Next/Err/Close cursor.
+type pager struct {
+	pages [][]string
+	cur   []string
+}
+
+func (p *pager) Next() bool {
+	if len(p.pages) == 0 {
+		return false
+	}
+	p.cur, p.pages = p.pages[0], p.pages[1:]
+	return true
+}
+
+func (p *pager) Page() []string { return p.cur }
+func (p *pager) Err() error     { return nil }
+
+func (p *pager) Close() error {
+	fmt.Println("closed")
+	return nil
+}
+
+func ExampleFromCursor() {
+	p := &pager{pages: [][]string{{"a", "b"}, {"c"}, {"d", "e"}}}
+	pages := xsql.FromCursor(p, func(p *pager) ([]string, error) { return p.Page(), nil })
+	for page, err := range xiter.Take2(pages, 2) {
+		if err != nil {
+			break
+		}
+		fmt.Println(page)
+	}
+	// Output:
+	// [a b]
+	// [c]
+	// closed
+}
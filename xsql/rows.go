@@ -0,0 +1,55 @@
+package xsql
+
+import (
+	"database/sql"
+	"iter"
+)
+
+// Cursor is the iteration protocol shared by *sql.Rows and similar APIs: Next
+// advances to the next item and reports whether there is one, Err reports
+// the error that stopped iteration, if any, and Close releases the cursor.
+type Cursor interface {
+	Next() bool
+	Err() error
+	Close() error
+}
+
+// FromRows yields one value per row of rows, as produced by scan. It is
+// FromCursor specialized to *sql.Rows.
+func FromRows[T any](rows *sql.Rows, scan func(*sql.Rows) (T, error)) iter.Seq2[T, error] {
+	return FromCursor(rows, scan)
+}
+
+// FromCursor yields one value per item of c, as produced by scan, which is
+// called after each successful Next to read the current item. A scan error
+// is yielded with whatever value scan returned, and iteration continues with
+// the next item.
+//
+// When c is exhausted, c.Err is checked, then c is closed; the first of the
+// two errors, if any, is yielded as the final pair. When the consumer stops
+// early, c is closed and its error discarded. Either way the sequence is
+// single-use, since it leaves c closed.
+func FromCursor[C Cursor, T any](c C, scan func(C) (T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		exhausted := false
+		defer func() {
+			if !exhausted {
+				c.Close()
+			}
+		}()
+		for c.Next() {
+			if !yield(scan(c)) {
+				return
+			}
+		}
+		exhausted = true
+		err := c.Err()
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
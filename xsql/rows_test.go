@@ -0,0 +1,213 @@
+package xsql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-board/xiter"
+)
+
+// The stub driver serves canned result sets without a database server. A
+// query string names the result set: "n" returns the rows 1..n, and "n!"
+// returns them followed by errStub from the driver's Next.
+
+var errStub = errors.New("stub: connection lost")
+
+type stubDriver struct{}
+
+func (stubDriver) Open(string) (driver.Conn, error) { return stubConn{}, nil }
+
+type stubConn struct{}
+
+func (stubConn) Prepare(query string) (driver.Stmt, error) { return stubStmt(query), nil }
+func (stubConn) Close() error                              { return nil }
+func (stubConn) Begin() (driver.Tx, error)                 { return nil, errors.New("stub: no transactions") }
+
+type stubStmt string
+
+func (stubStmt) Close() error                               { return nil }
+func (stubStmt) NumInput() int                              { return 0 }
+func (stubStmt) Exec([]driver.Value) (driver.Result, error) { return nil, errors.New("stub: no exec") }
+
+func (s stubStmt) Query([]driver.Value) (driver.Rows, error) {
+	q := string(s)
+	fail := q != "" && q[len(q)-1] == '!'
+	if fail {
+		q = q[:len(q)-1]
+	}
+	n, err := strconv.Atoi(q)
+	if err != nil {
+		return nil, err
+	}
+	stubOpen.Add(1)
+	return &stubRows{n: n, fail: fail}, nil
+}
+
+// stubOpen counts result sets that have been opened and not yet closed.
+var stubOpen atomic.Int32
+
+func checkClosed(t *testing.T) {
+	t.Helper()
+	if n := stubOpen.Load(); n != 0 {
+		t.Fatalf("%d result sets left open", n)
+	}
+}
+
+type stubRows struct {
+	i, n   int
+	fail   bool
+	closed bool
+}
+
+func (r *stubRows) Columns() []string { return []string{"id", "name"} }
+
+func (r *stubRows) Close() error {
+	if !r.closed {
+		r.closed = true
+		stubOpen.Add(-1)
+	}
+	return nil
+}
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	if r.i == r.n {
+		if r.fail {
+			return errStub
+		}
+		return io.EOF
+	}
+	r.i++
+	dest[0] = int64(r.i)
+	if r.i == 2 {
+		dest[1] = nil // makes scanning into a string fail
+	} else {
+		dest[1] = "row" + strconv.Itoa(r.i)
+	}
+	return nil
+}
+
+var registerStub sync.Once
+
+func openStub(t *testing.T) *sql.DB {
+	t.Helper()
+	registerStub.Do(func() { sql.Register("xsqlstub", stubDriver{}) })
+	db, err := sql.Open("xsqlstub", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+type row struct {
+	ID   int
+	Name string
+}
+
+func scanRow(r *sql.Rows) (row, error) {
+	var v row
+	err := r.Scan(&v.ID, &v.Name)
+	return v, err
+}
+
+func query(t *testing.T, db *sql.DB, q string) *sql.Rows {
+	t.Helper()
+	rows, err := db.Query(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+func TestFromRows(t *testing.T) {
+	db := openStub(t)
+	var ids []int
+	var errs []error
+	for v, err := range FromRows(query(t, db, "3"), scanRow) {
+		ids = append(ids, v.ID)
+		errs = append(errs, err)
+	}
+	if !reflect.DeepEqual(ids, []int{1, 2, 3}) {
+		t.Fatalf("got ids %v, want [1 2 3]", ids)
+	}
+	if errs[0] != nil || errs[1] == nil || errs[2] != nil {
+		t.Fatalf("got errors %v, want a scan error for row 2 only", errs)
+	}
+	checkClosed(t)
+}
+
+func TestFromRowsErr(t *testing.T) {
+	db := openStub(t)
+	var errs []error
+	for _, err := range FromRows(query(t, db, "1!"), scanRow) {
+		errs = append(errs, err)
+	}
+	if len(errs) != 2 || errs[0] != nil || !errors.Is(errs[1], errStub) {
+		t.Fatalf("got errors %v, want [nil %v]", errs, errStub)
+	}
+	checkClosed(t)
+}
+
+func TestFromRowsEarlyStop(t *testing.T) {
+	db := openStub(t)
+	got, err := xiter.CollectErr(xiter.Take2(FromRows(query(t, db, "100"), scanRow), 1))
+	if err != nil || !reflect.DeepEqual(got, []row{{1, "row1"}}) {
+		t.Fatalf("got (%v, %v)", got, err)
+	}
+	v, _, ok := xiter.FirstFunc2(FromRows(query(t, db, "100"), scanRow), func(v row, _ error) bool { return v.ID == 3 })
+	if !ok || v.ID != 3 {
+		t.Fatalf("got (%v, %v)", v, ok)
+	}
+	checkClosed(t)
+}
+
+// sliceCursor is a minimal non-database Cursor.
+type sliceCursor struct {
+	items  []string
+	pos    int
+	err    error
+	closed int
+}
+
+func (c *sliceCursor) Next() bool {
+	if c.closed > 0 || c.pos >= len(c.items) {
+		return false
+	}
+	c.pos++
+	return true
+}
+
+func (c *sliceCursor) Value() string { return c.items[c.pos-1] }
+func (c *sliceCursor) Err() error    { return c.err }
+func (c *sliceCursor) Close() error  { c.closed++; return nil }
+
+func TestFromCursor(t *testing.T) {
+	value := func(c *sliceCursor) (string, error) { return c.Value(), nil }
+
+	c := &sliceCursor{items: []string{"a", "b", "c"}}
+	got, err := xiter.CollectErr(FromCursor(c, value))
+	if err != nil || !reflect.DeepEqual(got, []string{"a", "b", "c"}) || c.closed != 1 {
+		t.Fatalf("got (%v, %v), closed %d times", got, err, c.closed)
+	}
+
+	c = &sliceCursor{items: []string{"a", "b"}, err: errStub}
+	got, err = xiter.CollectErr(FromCursor(c, value))
+	if !errors.Is(err, errStub) || !reflect.DeepEqual(got, []string{"a", "b"}) || c.closed != 1 {
+		t.Fatalf("got (%v, %v), closed %d times", got, err, c.closed)
+	}
+
+	c = &sliceCursor{items: []string{"a", "b"}}
+	for range FromCursor(c, value) {
+		break
+	}
+	if c.closed != 1 || c.pos != 1 {
+		t.Fatalf("early stop read %d items, closed %d times", c.pos, c.closed)
+	}
+}